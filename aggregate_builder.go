@@ -0,0 +1,188 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/gflydev/core/errors"
+	qb "github.com/jivegroup/fluentsql"
+)
+
+// ====================================================================
+//                        Aggregate functions
+// ====================================================================
+
+// Count returns the number of rows matching the query.
+// With GROUP BY or HAVING clauses, it returns the number of groups.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the query.
+//
+// Returns:
+//   - int64: The number of rows.
+//   - error: An error object if any issues occur during the query; nil otherwise.
+//
+// Example:
+//
+//	total, err := mb.Instance().Model(&User{}).Where("status", mb.Eq, "active").Count(ctx)
+//	// Executes: SELECT COUNT(*) AS total FROM users WHERE status = $1
+func (db *DBModel) Count(ctx context.Context) (total int64, err error) {
+	defer db.reset()
+
+	var queryBuilder *qb.QueryBuilder
+	var sqlStr string
+	var args []any
+
+	if len(db.groupByStatement.Items) > 0 || len(db.havingStatement.Conditions) > 0 {
+		// Count the groups
+		groupColumns := []any{"1"}
+		for _, item := range db.groupByStatement.Items {
+			groupColumns = append(groupColumns, item)
+		}
+
+		if queryBuilder, _, err = db.baseQuery(groupColumns...); err != nil {
+			return
+		}
+
+		sqlStr, args, _ = queryBuilder.Sql()
+		sqlStr = fmt.Sprintf("SELECT COUNT(*) AS total FROM (%s) _result_out_", sqlStr)
+	} else {
+		if queryBuilder, _, err = db.baseQuery("COUNT(*) AS total"); err != nil {
+			return
+		}
+
+		sqlStr, args, _ = queryBuilder.Sql()
+	}
+
+	err = db.getRawContext(ctx, sqlStr, args, &total)
+
+	return
+}
+
+// Sum returns the sum of the values of a column. It returns 0 when no row matches.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the query.
+//   - field (string): The column or SQL expression to sum.
+//
+// Returns:
+//   - float64: The sum of the values.
+//   - error: An error object if any issues occur during the query; nil otherwise.
+//
+// Example:
+//
+//	amount, err := mb.Instance().Model(&Order{}).Where("status", mb.Eq, "paid").Sum(ctx, "amount")
+func (db *DBModel) Sum(ctx context.Context, field string) (float64, error) {
+	var value sql.NullFloat64
+
+	err := db.aggregate(ctx, fmt.Sprintf("SUM(%s)", field), &value)
+
+	return value.Float64, err
+}
+
+// Avg returns the average of the values of a column. It returns 0 when no row matches.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the query.
+//   - field (string): The column or SQL expression to average.
+//
+// Returns:
+//   - float64: The average of the values.
+//   - error: An error object if any issues occur during the query; nil otherwise.
+func (db *DBModel) Avg(ctx context.Context, field string) (float64, error) {
+	var value sql.NullFloat64
+
+	err := db.aggregate(ctx, fmt.Sprintf("AVG(%s)", field), &value)
+
+	return value.Float64, err
+}
+
+// Min scans the minimum value of a column into dest.
+// Use a nullable destination (e.g. sql.NullTime) when no row may match.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the query.
+//   - field (string): The column or SQL expression.
+//   - dest (any): A pointer receiving the value (e.g. *int, *time.Time, *sql.NullString).
+//
+// Returns:
+//   - error: An error object if any issues occur during the query; nil otherwise.
+//
+// Example:
+//
+//	var first sql.NullTime
+//	err := mb.Instance().Model(&User{}).Min(ctx, "created_at", &first)
+func (db *DBModel) Min(ctx context.Context, field string, dest any) error {
+	return db.aggregate(ctx, fmt.Sprintf("MIN(%s)", field), dest)
+}
+
+// Max scans the maximum value of a column into dest.
+// Use a nullable destination (e.g. sql.NullTime) when no row may match.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the query.
+//   - field (string): The column or SQL expression.
+//   - dest (any): A pointer receiving the value (e.g. *int, *time.Time, *sql.NullString).
+//
+// Returns:
+//   - error: An error object if any issues occur during the query; nil otherwise.
+func (db *DBModel) Max(ctx context.Context, field string, dest any) error {
+	return db.aggregate(ctx, fmt.Sprintf("MAX(%s)", field), dest)
+}
+
+// Exists reports whether at least one row matches the query.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the query.
+//
+// Returns:
+//   - bool: True if a row matches.
+//   - error: An error object if any issues occur during the query; nil otherwise.
+//
+// Example:
+//
+//	found, err := mb.Instance().Model(&User{}).Where("email", mb.Eq, email).Exists(ctx)
+//	// Executes: SELECT EXISTS (SELECT 1 FROM users WHERE email = $1) AS result
+func (db *DBModel) Exists(ctx context.Context) (found bool, err error) {
+	defer db.reset()
+
+	var queryBuilder *qb.QueryBuilder
+
+	if queryBuilder, _, err = db.baseQuery("1"); err != nil {
+		return
+	}
+
+	sqlStr, args, _ := queryBuilder.Sql()
+	sqlStr = fmt.Sprintf("SELECT EXISTS (%s) AS result", sqlStr)
+
+	err = db.getRawContext(ctx, sqlStr, args, &found)
+
+	return
+}
+
+// aggregate scans the result of an aggregate function over the rows matching the query.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the query.
+//   - function (string): The aggregate function (e.g. "SUM(amount)").
+//   - dest (any): A pointer receiving the value.
+//
+// Returns:
+//   - error: An error object if any issues occur during the query; nil otherwise.
+func (db *DBModel) aggregate(ctx context.Context, function string, dest any) error {
+	defer db.reset()
+
+	// One value per group can't be scanned into a single destination
+	if len(db.groupByStatement.Items) > 0 {
+		return errors.New("GROUP BY is not supported by %s, use Select and Find instead", function)
+	}
+
+	queryBuilder, _, err := db.baseQuery(function + " AS result")
+	if err != nil {
+		return err
+	}
+
+	sqlStr, args, _ := queryBuilder.Sql()
+
+	return db.getRawContext(ctx, sqlStr, args, dest)
+}
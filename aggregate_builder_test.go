@@ -0,0 +1,196 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+
+	qb "github.com/jivegroup/fluentsql"
+)
+
+func TestAggregates(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		run      func() (any, error)
+		value    driver.Value
+		expected string
+		result   any
+	}{
+		{
+			name: "Count",
+			run: func() (any, error) {
+				return Instance().Model(&unionPost{}).Where("title", Eq, "go").Count(ctx)
+			},
+			value:    int64(5),
+			expected: "SELECT COUNT(*) AS total FROM posts WHERE title = $1",
+			result:   int64(5),
+		},
+		{
+			name: "Count of groups",
+			run: func() (any, error) {
+				return Instance().Model(&unionPost{}).GroupBy("title").Having("COUNT(*)", Greater, 1).Count(ctx)
+			},
+			value: int64(2),
+			expected: "SELECT COUNT(*) AS total FROM " +
+				"(SELECT 1, title FROM posts GROUP BY title HAVING COUNT(*) > $1) _result_out_",
+			result: int64(2),
+		},
+		{
+			name: "Sum",
+			run: func() (any, error) {
+				return Instance().Model(&unionPost{}).Sum(ctx, "score")
+			},
+			value:    7.5,
+			expected: "SELECT SUM(score) AS result FROM posts",
+			result:   7.5,
+		},
+		{
+			name: "Sum of no row",
+			run: func() (any, error) {
+				return Instance().Model(&unionPost{}).Sum(ctx, "score")
+			},
+			value:    nil,
+			expected: "SELECT SUM(score) AS result FROM posts",
+			result:   0.0,
+		},
+		{
+			name: "Avg of no row",
+			run: func() (any, error) {
+				return Instance().Model(&unionPost{}).Where("id", Greater, 10).Avg(ctx, "score")
+			},
+			value:    nil,
+			expected: "SELECT AVG(score) AS result FROM posts WHERE id > $1",
+			result:   0.0,
+		},
+		{
+			name: "Min",
+			run: func() (any, error) {
+				var value int
+				err := Instance().Model(&unionPost{}).Min(ctx, "id", &value)
+
+				return value, err
+			},
+			value:    int64(3),
+			expected: "SELECT MIN(id) AS result FROM posts",
+			result:   3,
+		},
+		{
+			name: "Max of no row",
+			run: func() (any, error) {
+				var value sql.NullString
+				err := Instance().Model(&unionPost{}).Max(ctx, "title", &value)
+
+				return value, err
+			},
+			value:    nil,
+			expected: "SELECT MAX(title) AS result FROM posts",
+			result:   sql.NullString{},
+		},
+		{
+			name: "Exists",
+			run: func() (any, error) {
+				return Instance().Model(&unionPost{}).Where("title", Eq, "go").Exists(ctx)
+			},
+			value:    true,
+			expected: "SELECT EXISTS (SELECT 1 FROM posts WHERE title = $1) AS result",
+			result:   true,
+		},
+		{
+			name: "CountModels",
+			run: func() (any, error) {
+				return CountModels[unionPost](Condition{Field: "title", Opt: Eq, Value: "go"})
+			},
+			value:    int64(1),
+			expected: "SELECT COUNT(*) AS total FROM posts WHERE title = $1",
+			result:   int64(1),
+		},
+		{
+			name: "SumModels",
+			run: func() (any, error) {
+				return SumModels[unionPost]("score")
+			},
+			value:    2.5,
+			expected: "SELECT SUM(score) AS result FROM posts",
+			result:   2.5,
+		},
+		{
+			name: "AvgModels",
+			run: func() (any, error) {
+				return AvgModels[unionPost]("score", Condition{Field: "id", Opt: Lesser, Value: 5})
+			},
+			value:    1.5,
+			expected: "SELECT AVG(score) AS result FROM posts WHERE id < $1",
+			result:   1.5,
+		},
+		{
+			name: "MinModels",
+			run: func() (any, error) {
+				return MinModels[unionPost, sql.NullString]("title")
+			},
+			value:    "a",
+			expected: "SELECT MIN(title) AS result FROM posts",
+			result:   sql.NullString{String: "a", Valid: true},
+		},
+		{
+			name: "MaxModels",
+			run: func() (any, error) {
+				return MaxModels[unionPost, int]("id")
+			},
+			value:    int64(9),
+			expected: "SELECT MAX(id) AS result FROM posts",
+			result:   9,
+		},
+		{
+			name: "ExistsModel",
+			run: func() (any, error) {
+				return ExistsModel[unionPost](Condition{Field: "title", Opt: Eq, Value: "go"})
+			},
+			value:    false,
+			expected: "SELECT EXISTS (SELECT 1 FROM posts WHERE title = $1) AS result",
+			result:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeDB(t, new(qb.PostgreSQLDialect), fakeResult{
+				columns: []string{"result"},
+				rows:    [][]driver.Value{{tt.value}},
+			})
+
+			result, err := tt.run()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(result, tt.result) {
+				t.Errorf("result = %#v, expected %#v", result, tt.result)
+			}
+
+			if len(fake.statements) != 1 || fake.statements[0] != tt.expected {
+				t.Errorf("statements = %q, expected %q", fake.statements, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAggregateWithGroupBy(t *testing.T) {
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect))
+
+	db := Instance().Model(&unionPost{}).GroupBy("title")
+	if _, err := db.Sum(context.Background(), "score"); err == nil {
+		t.Error("Sum() with GROUP BY expected an error")
+	}
+
+	if len(fake.statements) != 0 {
+		t.Errorf("statements = %q, expected none", fake.statements)
+	}
+
+	if len(db.groupByStatement.Items) != 0 {
+		t.Error("Sum() expected the query to be reset")
+	}
+}
@@ -0,0 +1,220 @@
+package db
+
+import (
+	"context"
+	"github.com/gflydev/core/errors"
+	qb "github.com/jivegroup/fluentsql"
+	"reflect"
+)
+
+// ====================================================================
+//                         Batch processing
+// ====================================================================
+
+// Chunk retrieves the rows of the query in batches of the given size and calls fn with each batch.
+// Batches are paged with LIMIT and OFFSET, sorted by the ORDER BY clause or by the primary key.
+// Use ChunkByID when fn updates or deletes the rows of the query, because paging by offset would
+// skip rows. Returning an error from fn stops the iteration and returns the error.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the queries.
+//   - size (int): The number of rows per batch.
+//   - fn (any): The function processing a batch, a `func([]T) error` where T is the model Struct.
+//     A new slice is allocated per batch.
+//
+// Returns:
+//   - error: The error of fn, or an error object if fn is invalid or any issues occur during a query; nil otherwise.
+//
+// Example:
+//
+//	err := mb.Instance().Where("status", mb.Eq, "active").Chunk(ctx, 1000, func(batch []User) error {
+//	    return exportUsers(batch)
+//	})
+func (db *DBModel) Chunk(ctx context.Context, size int, fn any) error {
+	return db.chunk(ctx, size, fn, false)
+}
+
+// ChunkByID retrieves the rows of the query in batches of the given size and calls fn with each
+// batch. Batches are paged with `WHERE id > lastID ORDER BY id LIMIT size` on the primary key, so
+// that fn can update or delete the rows without skipping or repeating any. ORDER BY, LIMIT and
+// FETCH clauses of the query are replaced. Returning an error from fn stops the iteration and
+// returns the error.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the queries.
+//   - size (int): The number of rows per batch.
+//   - fn (any): The function processing a batch, a `func([]T) error` where T is the model Struct.
+//     A new slice is allocated per batch.
+//
+// Returns:
+//   - error: The error of fn, or an error object if fn is invalid or any issues occur during a query; nil otherwise.
+//
+// Example:
+//
+//	err := mb.Instance().Where("email_verified", mb.Null, nil).ChunkByID(ctx, 1000, func(batch []User) error {
+//	    for _, user := range batch {
+//	        // Backfill the column
+//	    }
+//	    return nil
+//	})
+//	// Executes: SELECT * FROM users WHERE email_verified IS NULL AND users.id > $1
+//	//           ORDER BY users.id ASC LIMIT 1000 OFFSET 0
+func (db *DBModel) ChunkByID(ctx context.Context, size int, fn any) error {
+	return db.chunk(ctx, size, fn, true)
+}
+
+// ChunkOf retrieves the rows of type T in batches of the given size. Unlike Chunk, the type of fn
+// is checked at compile time. See Chunk.
+//
+// Generic Type:
+//   - T: The type of the model.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the queries.
+//   - db (*DBModel): The query to process.
+//   - size (int): The number of rows per batch.
+//   - fn (func([]T) error): The function processing a batch.
+//
+// Returns:
+//   - error: The error of fn, or an error object if any issues occur during a query; nil otherwise.
+//
+// Example:
+//
+//	err := mb.ChunkOf(ctx, mb.Instance().Where("status", mb.Eq, "active"), 1000, func(batch []User) error {
+//	    return exportUsers(batch)
+//	})
+func ChunkOf[T any](ctx context.Context, db *DBModel, size int, fn func([]T) error) error {
+	return db.Chunk(ctx, size, fn)
+}
+
+// ChunkByIDOf retrieves the rows of type T in batches of the given size, paged by primary key.
+// Unlike ChunkByID, the type of fn is checked at compile time. See ChunkByID.
+//
+// Generic Type:
+//   - T: The type of the model.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the queries.
+//   - db (*DBModel): The query to process.
+//   - size (int): The number of rows per batch.
+//   - fn (func([]T) error): The function processing a batch.
+//
+// Returns:
+//   - error: The error of fn, or an error object if any issues occur during a query; nil otherwise.
+func ChunkByIDOf[T any](ctx context.Context, db *DBModel, size int, fn func([]T) error) error {
+	return db.ChunkByID(ctx, size, fn)
+}
+
+// errorType is the type of the error interface.
+var errorType = reflect.TypeFor[error]()
+
+// chunk retrieves the rows of the query in batches, paged by offset or by primary key.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the queries.
+//   - size (int): The number of rows per batch.
+//   - fn (any): The `func([]T) error` processing a batch.
+//   - byID (bool): Whether the batches are paged by primary key.
+//
+// Returns:
+//   - error: The error of fn, or an error object if any issues occur during a query; nil otherwise.
+func (db *DBModel) chunk(ctx context.Context, size int, fn any, byID bool) error {
+	defer db.reset()
+
+	if db.raw.sqlStr != "" {
+		return errors.New("Raw SQL queries are not supported by Chunk")
+	}
+
+	fnValue := reflect.ValueOf(fn)
+	fnType := reflect.TypeOf(fn)
+	if fnType == nil || fnType.Kind() != reflect.Func || fnValue.IsNil() ||
+		fnType.NumIn() != 1 || fnType.In(0).Kind() != reflect.Slice ||
+		fnType.NumOut() != 1 || fnType.Out(0) != errorType {
+		return errors.New("Invalid callback :: fn not func([]T) error type")
+	}
+
+	if size < 1 {
+		return errors.New("Invalid size %d :: size must be greater than 0", size)
+	}
+
+	// Each batch is loaded into a slice of the callback's parameter type
+	items := reflect.New(fnType.In(0))
+	slice := items.Elem()
+
+	// Take the model from the items when it is not set
+	if db.model == nil {
+		db.model = reflect.New(scopeModelType(fnType.In(0))).Interface()
+	}
+
+	table, err := db.modelTable()
+	if err != nil {
+		return err
+	}
+
+	if len(table.Primaries) == 0 && (byID || len(db.orderByStatement.Items) == 0) {
+		return errors.New("Chunk requires a primary key to sort the rows")
+	}
+
+	var primary string
+	if len(table.Primaries) > 0 {
+		primary = table.Name + "." + table.Primaries[0].Name
+	}
+
+	// Batches are paged by LIMIT only
+	db.fetchStatement.Fetch = 0
+
+	// Stable order of the batches
+	if byID {
+		db.orderByStatement.Items = []qb.SortItem{}
+		db.OrderBy(primary, Asc)
+	} else if len(db.orderByStatement.Items) == 0 {
+		db.OrderBy(primary, Asc)
+	}
+
+	// Each batch restarts from the query, which find resets
+	state := *db
+
+	var lastID any
+	for offset := 0; ; offset += size {
+		*db = state
+
+		if byID {
+			if lastID != nil {
+				db.groupConditionsFrom(0)
+				db.Where(primary, Greater, lastID)
+			}
+
+			db.Limit(size, 0)
+		} else {
+			db.Limit(size, offset)
+		}
+
+		slice.Set(reflect.Zero(slice.Type()))
+
+		if _, err = db.WithoutCount().find(ctx, items.Interface()); err != nil {
+			return err
+		}
+
+		count := slice.Len()
+		if count == 0 {
+			return nil
+		}
+
+		if byID {
+			var lastTable *Table
+			if lastTable, err = ModelData(slice.Index(count - 1).Interface()); err != nil {
+				return err
+			}
+
+			lastID = lastTable.Values[table.Primaries[0].Name]
+		}
+
+		if result := fnValue.Call([]reflect.Value{slice})[0]; !result.IsNil() {
+			return result.Interface().(error)
+		}
+
+		if count < size {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package db
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+
+	qb "github.com/jivegroup/fluentsql"
+)
+
+type chunkUser struct {
+	MetaData MetaData `db:"-" model:"table:users"`
+	ID       int      `db:"id" model:"name:id; type:serial,primary"`
+}
+
+// chunkRows returns the results of two batches: a full one and a partial one.
+func chunkRows() []fakeResult {
+	return []fakeResult{
+		{columns: []string{"id"}, rows: [][]driver.Value{{int64(1)}, {int64(2)}}},
+		{columns: []string{"id"}, rows: [][]driver.Value{{int64(3)}}},
+	}
+}
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		name     string
+		chunk    func(db *DBModel, fn func([]chunkUser) error) error
+		expected []string
+	}{
+		{
+			name: "Chunk",
+			chunk: func(db *DBModel, fn func([]chunkUser) error) error {
+				return db.Chunk(t.Context(), 2, fn)
+			},
+			expected: []string{
+				"SELECT * FROM users ORDER BY users.id ASC LIMIT $1 OFFSET $2",
+				"SELECT * FROM users ORDER BY users.id ASC LIMIT $1 OFFSET $2",
+			},
+		},
+		{
+			name: "ChunkByIDOf",
+			chunk: func(db *DBModel, fn func([]chunkUser) error) error {
+				return ChunkByIDOf(t.Context(), db, 2, fn)
+			},
+			expected: []string{
+				"SELECT * FROM users ORDER BY users.id ASC LIMIT $1 OFFSET $2",
+				"SELECT * FROM users WHERE users.id > $1 ORDER BY users.id ASC LIMIT $2 OFFSET $3",
+			},
+		},
+		{
+			name: "ChunkByID of Table",
+			chunk: func(db *DBModel, fn func([]chunkUser) error) error {
+				return db.Table("archived_users").ChunkByID(t.Context(), 2, fn)
+			},
+			expected: []string{
+				"SELECT * FROM archived_users ORDER BY archived_users.id ASC LIMIT $1 OFFSET $2",
+				"SELECT * FROM archived_users WHERE archived_users.id > $1 ORDER BY archived_users.id ASC LIMIT $2 OFFSET $3",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeDB(t, new(qb.PostgreSQLDialect), chunkRows()...)
+
+			var batches [][]int
+			err := tt.chunk(Instance(), func(batch []chunkUser) error {
+				var ids []int
+				for _, user := range batch {
+					ids = append(ids, user.ID)
+				}
+				batches = append(batches, ids)
+
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if expected := [][]int{{1, 2}, {3}}; !reflect.DeepEqual(batches, expected) {
+				t.Errorf("batches = %v, expected %v", batches, expected)
+			}
+
+			if !reflect.DeepEqual(fake.statements, tt.expected) {
+				t.Errorf("statements = %q, expected %q", fake.statements, tt.expected)
+			}
+		})
+	}
+}
+
+func TestChunkStopsOnError(t *testing.T) {
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect), chunkRows()...)
+
+	errStop := errors.New("stop")
+	err := Instance().Chunk(t.Context(), 2, func(batch []chunkUser) error {
+		return errStop
+	})
+
+	if !errors.Is(err, errStop) {
+		t.Errorf("Chunk() error = %v, expected %v", err, errStop)
+	}
+
+	if len(fake.statements) != 1 {
+		t.Errorf("statements = %q, expected one batch", fake.statements)
+	}
+}
+
+func TestChunkInvalidCallback(t *testing.T) {
+	callbacks := []any{
+		nil,
+		1,
+		(func([]chunkUser) error)(nil),
+		func() error { return nil },
+		func(chunkUser) error { return nil },
+		func([]chunkUser) {},
+		func([]chunkUser) bool { return true },
+	}
+
+	for _, fn := range callbacks {
+		fake := useFakeDB(t, new(qb.PostgreSQLDialect))
+
+		if err := Instance().Chunk(t.Context(), 2, fn); err == nil {
+			t.Errorf("Chunk(%T) expected an error", fn)
+		}
+
+		if len(fake.statements) != 0 {
+			t.Errorf("Chunk(%T) statements = %q, expected none", fn, fake.statements)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package db
+
+import (
+	"fmt"
+	"github.com/gflydev/core/errors"
+	qb "github.com/jivegroup/fluentsql"
+	"strings"
+)
+
+// ====================================================================
+//                              Upsert
+// ====================================================================
+
+// Conflict represents how Create handles rows conflicting with existing rows on a unique index.
+//
+// Fields:
+//   - Columns ([]string): The columns of the unique index (conflict target). Required by
+//     PostgreSQL and SQLite to update the row. MySQL checks every unique index and ignores it.
+//   - DoUpdate ([]string): The columns updated with the values of the inserted row.
+//   - UpdateAll (bool): Update all inserted columns except primary keys and creation timestamps.
+//   - DoNothing (bool): Keep the existing row and skip the insertion.
+type Conflict struct {
+	Columns   []string
+	DoUpdate  []string
+	UpdateAll bool
+	DoNothing bool
+}
+
+// OnConflict sets how the next Create handles rows conflicting with existing rows on a unique index
+// (upsert). PostgreSQL and SQLite use `ON CONFLICT (...) DO UPDATE SET col = EXCLUDED.col` or
+// `DO NOTHING`, MySQL uses `ON DUPLICATE KEY UPDATE col = VALUES(col)` or `INSERT IGNORE`. The serial
+// primary key of the inserted or updated row is set back on the model. When DoNothing skips a row, the
+// primary key is not set.
+//
+// Parameters:
+//   - conflict (Conflict): The conflict handling.
+//
+// Returns:
+//   - *DBModel: A reference to the DBModel instance for chaining.
+//
+// Examples:
+//
+//	err := mb.Instance().OnConflict(mb.Conflict{
+//	    Columns:  []string{"email"},
+//	    DoUpdate: []string{"fullname", "updated_at"},
+//	}).Create(&user)
+//	// PostgreSQL: INSERT INTO users (...) VALUES (...) ON CONFLICT (email)
+//	//             DO UPDATE SET fullname = EXCLUDED.fullname, updated_at = EXCLUDED.updated_at RETURNING id
+//	// MySQL:      INSERT INTO users (...) VALUES (...) ON DUPLICATE KEY
+//	//             UPDATE fullname = VALUES(fullname), updated_at = VALUES(updated_at), id = LAST_INSERT_ID(id)
+//
+//	// PostgreSQL: INSERT INTO users (...) VALUES (...), (...) ON CONFLICT DO NOTHING RETURNING id
+//	// MySQL:      INSERT IGNORE INTO users (...) VALUES (...), (...)
+//	err := mb.Instance().OnConflict(mb.Conflict{DoNothing: true}).Create(users)
+func (db *DBModel) OnConflict(conflict Conflict) *DBModel {
+	db.conflict = &conflict
+
+	return db
+}
+
+// apply adds the conflict handling to an INSERT statement for the current dialect.
+// MySQL skips conflicting rows with INSERT IGNORE, so that they are not counted as affected rows,
+// even when the connection reports the found rows (clientFoundRows).
+//
+// Parameters:
+//   - sqlStr (string): The INSERT statement.
+//   - table (*Table): The table of the inserted model.
+//   - columns ([]string): The inserted columns.
+//
+// Returns:
+//   - string: The INSERT statement handling the conflicts.
+//   - error: An error if the conflict handling is incomplete.
+func (c *Conflict) apply(sqlStr string, table *Table, columns []string) (string, error) {
+	if c.DoNothing && qb.IsDialect(qb.MySQL) {
+		return strings.Replace(sqlStr, "INSERT INTO", "INSERT IGNORE INTO", 1), nil
+	}
+
+	conflictClause, err := c.clause(table, columns)
+	if err != nil {
+		return "", err
+	}
+
+	return sqlStr + " " + conflictClause, nil
+}
+
+// clause generates the conflict clause of an INSERT statement for the current dialect.
+//
+// Parameters:
+//   - table (*Table): The table of the inserted model.
+//   - columns ([]string): The inserted columns.
+//
+// Returns:
+//   - string: The conflict clause.
+//   - error: An error if the conflict handling is incomplete.
+func (c *Conflict) clause(table *Table, columns []string) (string, error) {
+	var updates []string
+
+	if !c.DoNothing {
+		updates = c.DoUpdate
+
+		if c.UpdateAll {
+			updates = nil
+
+			for _, name := range columns {
+				if column := table.column(name); column != nil && (column.Primary || column.AutoCreateTime) {
+					continue
+				}
+
+				updates = append(updates, name)
+			}
+		}
+
+		if len(updates) == 0 {
+			return "", errors.New("Invalid conflict :: set DoNothing, DoUpdate or UpdateAll")
+		}
+	}
+
+	if qb.IsDialect(qb.MySQL) {
+		return c.mysqlClause(table, updates), nil
+	}
+
+	target := ""
+	if len(c.Columns) > 0 {
+		target = fmt.Sprintf(" (%s)", strings.Join(c.Columns, ", "))
+	}
+
+	if len(updates) == 0 {
+		return "ON CONFLICT" + target + " DO NOTHING", nil
+	}
+
+	if target == "" {
+		return "", errors.New("Invalid conflict :: Columns are required to update the conflicting row")
+	}
+
+	assignments := make([]string, len(updates))
+	for i, name := range updates {
+		assignments[i] = fmt.Sprintf("%s = EXCLUDED.%s", name, name)
+	}
+
+	return "ON CONFLICT" + target + " DO UPDATE SET " + strings.Join(assignments, ", "), nil
+}
+
+// mysqlClause generates the ON DUPLICATE KEY UPDATE clause of MySQL.
+//
+// Parameters:
+//   - table (*Table): The table of the inserted model.
+//   - updates ([]string): The updated columns.
+//
+// Returns:
+//   - string: The conflict clause.
+func (c *Conflict) mysqlClause(table *Table, updates []string) string {
+	assignments := make([]string, 0, len(updates)+1)
+
+	for _, name := range updates {
+		assignments = append(assignments, fmt.Sprintf("%s = VALUES(%s)", name, name))
+	}
+
+	if table.PrimarySerial != nil {
+		// Give the ID of the updated row to LastInsertId
+		name := table.PrimarySerial.Name
+		assignments = append(assignments, fmt.Sprintf("%s = LAST_INSERT_ID(%s)", name, name))
+	}
+
+	return "ON DUPLICATE KEY UPDATE " + strings.Join(assignments, ", ")
+}
+
+// column returns the column of the table having the given name.
+//
+// Parameters:
+//   - name (string): The column name.
+//
+// Returns:
+//   - *Column: The column, or nil if the table has no such column.
+func (tbl *Table) column(name string) *Column {
+	for i := range tbl.Columns {
+		if tbl.Columns[i].Name == name {
+			return &tbl.Columns[i]
+		}
+	}
+
+	return nil
+}
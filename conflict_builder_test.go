@@ -0,0 +1,365 @@
+package db
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"testing"
+
+	qb "github.com/jivegroup/fluentsql"
+)
+
+type conflictUser struct {
+	MetaData MetaData `db:"-" model:"table:users"`
+	ID       int      `db:"id" model:"name:id; type:serial,primary"`
+	Email    string   `db:"email" model:"name:email"`
+}
+
+func TestConflictApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  qb.Dialect
+		conflict Conflict
+		expected string
+	}{
+		{
+			name:     "PostgreSQL do nothing",
+			dialect:  new(qb.PostgreSQLDialect),
+			conflict: Conflict{DoNothing: true},
+			expected: "INSERT INTO users (email) VALUES ($1) ON CONFLICT DO NOTHING",
+		},
+		{
+			name:     "PostgreSQL do update",
+			dialect:  new(qb.PostgreSQLDialect),
+			conflict: Conflict{Columns: []string{"email"}, DoUpdate: []string{"email"}},
+			expected: "INSERT INTO users (email) VALUES ($1) ON CONFLICT (email) DO UPDATE SET email = EXCLUDED.email",
+		},
+		{
+			name:     "PostgreSQL do nothing on columns",
+			dialect:  new(qb.PostgreSQLDialect),
+			conflict: Conflict{Columns: []string{"email"}, DoNothing: true},
+			expected: "INSERT INTO users (email) VALUES ($1) ON CONFLICT (email) DO NOTHING",
+		},
+		{
+			name:     "SQLite do nothing on columns",
+			dialect:  new(qb.SQLiteDialect),
+			conflict: Conflict{Columns: []string{"email"}, DoNothing: true},
+			expected: "INSERT INTO users (email) VALUES (?) ON CONFLICT (email) DO NOTHING",
+		},
+		{
+			name:     "SQLite do update",
+			dialect:  new(qb.SQLiteDialect),
+			conflict: Conflict{Columns: []string{"email"}, UpdateAll: true},
+			expected: "INSERT INTO users (email) VALUES (?) ON CONFLICT (email) DO UPDATE SET email = EXCLUDED.email",
+		},
+		{
+			name:     "MySQL do nothing",
+			dialect:  new(qb.MySQLDialect),
+			conflict: Conflict{DoNothing: true},
+			expected: "INSERT IGNORE INTO users (email) VALUES (?)",
+		},
+		{
+			name:     "MySQL do update",
+			dialect:  new(qb.MySQLDialect),
+			conflict: Conflict{DoUpdate: []string{"email"}},
+			expected: "INSERT INTO users (email) VALUES (?) ON DUPLICATE KEY UPDATE email = VALUES(email), id = LAST_INSERT_ID(id)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useFakeDB(t, tt.dialect)
+
+			table, err := ModelData(&conflictUser{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sqlStr, _, _ := qb.InsertInstance().Insert(table.Name, "email").Row("john@gfly.dev").Sql()
+
+			result, err := tt.conflict.apply(sqlStr, table, []string{"email"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result != tt.expected {
+				t.Errorf("apply() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCreateOrSkipMySQL(t *testing.T) {
+	tests := []struct {
+		name        string
+		result      fakeResult
+		expectedNew bool
+		expectedID  int
+	}{
+		{
+			name:        "inserted",
+			result:      fakeResult{affected: 1, lastInsertID: 7},
+			expectedNew: true,
+			expectedID:  7,
+		},
+		{
+			// The connection reports found rows, but INSERT IGNORE doesn't count a skipped row
+			name:        "duplicate",
+			result:      fakeResult{affected: 0, lastInsertID: 0},
+			expectedNew: false,
+			expectedID:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeDB(t, new(qb.MySQLDialect), tt.result)
+
+			user := conflictUser{Email: "john@gfly.dev"}
+
+			created, err := Instance().createOrSkip(&user, []string{"email"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if created != tt.expectedNew {
+				t.Errorf("createOrSkip() = %v, expected %v", created, tt.expectedNew)
+			}
+
+			if user.ID != tt.expectedID {
+				t.Errorf("user.ID = %d, expected %d", user.ID, tt.expectedID)
+			}
+
+			if len(fake.statements) != 1 || !strings.HasPrefix(fake.statements[0], "INSERT IGNORE INTO users") {
+				t.Errorf("statements = %q, expected an INSERT IGNORE", fake.statements)
+			}
+		})
+	}
+}
+
+func TestCreateOrSkip(t *testing.T) {
+	tests := []struct {
+		name        string
+		dialect     qb.Dialect
+		result      fakeResult
+		expectedNew bool
+		expectedID  int
+		expected    string
+	}{
+		{
+			name:        "PostgreSQL inserted",
+			dialect:     new(qb.PostgreSQLDialect),
+			result:      fakeResult{columns: []string{"id"}, rows: [][]driver.Value{{int64(7)}}},
+			expectedNew: true,
+			expectedID:  7,
+			expected:    "INSERT INTO users (email) VALUES ($1) ON CONFLICT (email) DO NOTHING RETURNING id",
+		},
+		{
+			// No row is returned for a skipped row
+			name:     "PostgreSQL duplicate",
+			dialect:  new(qb.PostgreSQLDialect),
+			result:   fakeResult{columns: []string{"id"}},
+			expected: "INSERT INTO users (email) VALUES ($1) ON CONFLICT (email) DO NOTHING RETURNING id",
+		},
+		{
+			name:        "SQLite inserted",
+			dialect:     new(qb.SQLiteDialect),
+			result:      fakeResult{columns: []string{"id"}, rows: [][]driver.Value{{int64(7)}}},
+			expectedNew: true,
+			expectedID:  7,
+			expected:    "INSERT INTO users (email) VALUES (?) ON CONFLICT (email) DO NOTHING RETURNING id",
+		},
+		{
+			name:     "SQLite duplicate",
+			dialect:  new(qb.SQLiteDialect),
+			result:   fakeResult{columns: []string{"id"}},
+			expected: "INSERT INTO users (email) VALUES (?) ON CONFLICT (email) DO NOTHING RETURNING id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeDB(t, tt.dialect, tt.result)
+
+			user := conflictUser{Email: "john@gfly.dev"}
+
+			created, err := Instance().createOrSkip(&user, []string{"email"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if created != tt.expectedNew {
+				t.Errorf("createOrSkip() = %v, expected %v", created, tt.expectedNew)
+			}
+
+			if user.ID != tt.expectedID {
+				t.Errorf("user.ID = %d, expected %d", user.ID, tt.expectedID)
+			}
+
+			if len(fake.statements) != 1 || fake.statements[0] != tt.expected {
+				t.Errorf("statements = %q, expected %q", fake.statements, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFirstOrInit(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   fakeResult
+		expected createUser
+	}{
+		{
+			name:     "found",
+			result:   fakeResult{columns: []string{"id", "email", "status"}, rows: [][]driver.Value{{int64(3), "john@gfly.dev", "active"}}},
+			expected: createUser{ID: 3, Email: "john@gfly.dev", Status: "active"},
+		},
+		{
+			name:     "not found",
+			result:   fakeResult{columns: []string{"id", "email", "status"}},
+			expected: createUser{Email: "john@gfly.dev", Status: "pending"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeDB(t, new(qb.PostgreSQLDialect), tt.result)
+
+			var user createUser
+			err := Instance().Where("email", Eq, "john@gfly.dev").FirstOrInit(&user, map[string]any{"status": "pending"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if user != tt.expected {
+				t.Errorf("user = %+v, expected %+v", user, tt.expected)
+			}
+
+			// The model is not saved
+			expected := "SELECT * FROM users WHERE email = $1 ORDER BY id ASC LIMIT $2 OFFSET $3"
+			if len(fake.statements) != 1 || fake.statements[0] != expected {
+				t.Errorf("statements = %q, expected %q", fake.statements, expected)
+			}
+		})
+	}
+}
+
+func TestFirstOrCreate(t *testing.T) {
+	fake := useFakeDB(t, new(qb.SQLiteDialect),
+		fakeResult{columns: []string{"id", "email", "status"}}, // Not found
+		fakeResult{columns: []string{"id"}, rows: [][]driver.Value{{int64(4)}}},
+	)
+
+	var user createUser
+	err := Instance().Where("email", Eq, "john@gfly.dev").FirstOrCreate(&user, map[string]any{"status": "pending"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedUser := createUser{ID: 4, Email: "john@gfly.dev", Status: "pending"}
+	if user != expectedUser {
+		t.Errorf("user = %+v, expected %+v", user, expectedUser)
+	}
+
+	expected := []string{
+		"SELECT * FROM users WHERE email = ? ORDER BY id ASC LIMIT ? OFFSET ?",
+		"INSERT INTO users (email, status) VALUES (?, ?) ON CONFLICT (email) DO NOTHING RETURNING id",
+	}
+	if !reflect.DeepEqual(fake.statements, expected) {
+		t.Errorf("statements = %q, expected %q", fake.statements, expected)
+	}
+}
+
+func TestUpdateOrCreate(t *testing.T) {
+	tests := []struct {
+		name         string
+		results      []fakeResult
+		expectedUser createUser
+		expected     []string
+	}{
+		{
+			name: "update",
+			results: []fakeResult{
+				{columns: []string{"id", "email", "status"}, rows: [][]driver.Value{{int64(3), "john@gfly.dev", "pending"}}},
+				{affected: 1},
+			},
+			expectedUser: createUser{ID: 3, Email: "john@gfly.dev", Status: "active"},
+			expected: []string{
+				"SELECT * FROM users WHERE email = $1 ORDER BY id ASC LIMIT $2 OFFSET $3 FOR UPDATE",
+				"UPDATE users SET email = $1, status = $2 WHERE id = $3",
+			},
+		},
+		{
+			name: "create",
+			results: []fakeResult{
+				{columns: []string{"id", "email", "status"}},
+				{columns: []string{"id"}, rows: [][]driver.Value{{int64(5)}}},
+			},
+			expectedUser: createUser{ID: 5, Email: "john@gfly.dev", Status: "active"},
+			expected: []string{
+				"SELECT * FROM users WHERE email = $1 ORDER BY id ASC LIMIT $2 OFFSET $3 FOR UPDATE",
+				"INSERT INTO users (email, status) VALUES ($1, $2) ON CONFLICT (email) DO NOTHING RETURNING id",
+			},
+		},
+		{
+			name: "created concurrently",
+			results: []fakeResult{
+				{columns: []string{"id", "email", "status"}},
+				{columns: []string{"id"}},
+				{columns: []string{"id", "email", "status"}, rows: [][]driver.Value{{int64(6), "john@gfly.dev", "pending"}}},
+				{affected: 1},
+			},
+			expectedUser: createUser{ID: 6, Email: "john@gfly.dev", Status: "active"},
+			expected: []string{
+				"SELECT * FROM users WHERE email = $1 ORDER BY id ASC LIMIT $2 OFFSET $3 FOR UPDATE",
+				"INSERT INTO users (email, status) VALUES ($1, $2) ON CONFLICT (email) DO NOTHING RETURNING id",
+				"SELECT * FROM users WHERE email = $1 ORDER BY id ASC LIMIT $2 OFFSET $3 FOR UPDATE",
+				"UPDATE users SET email = $1, status = $2 WHERE id = $3",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeDB(t, new(qb.PostgreSQLDialect), tt.results...)
+
+			var user createUser
+			err := Instance().Model(&user).UpdateOrCreate(
+				map[string]any{"email": "john@gfly.dev"},
+				map[string]any{"status": "active"},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if user != tt.expectedUser {
+				t.Errorf("user = %+v, expected %+v", user, tt.expectedUser)
+			}
+
+			if !reflect.DeepEqual(fake.statements, tt.expected) {
+				t.Errorf("statements = %q, expected %q", fake.statements, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFirstOrCreateDuplicateMySQL(t *testing.T) {
+	fake := useFakeDB(t, new(qb.MySQLDialect),
+		fakeResult{columns: []string{"id", "email"}}, // Not found
+		fakeResult{affected: 0},                      // Created concurrently
+		fakeResult{columns: []string{"id", "email"}, rows: [][]driver.Value{{int64(3), "john@gfly.dev"}}}, // Found again
+	)
+
+	var user conflictUser
+	if err := Instance().Where("email", Eq, "john@gfly.dev").FirstOrCreate(&user, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if user.ID != 3 {
+		t.Errorf("user.ID = %d, expected the ID of the existing record 3", user.ID)
+	}
+
+	if len(fake.statements) != 3 {
+		t.Fatalf("statements = %q, expected SELECT, INSERT IGNORE and SELECT", fake.statements)
+	}
+}
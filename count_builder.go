@@ -0,0 +1,251 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/gflydev/core/errors"
+	qb "github.com/jivegroup/fluentsql"
+	"github.com/jmoiron/sqlx/reflectx"
+	"reflect"
+)
+
+// ====================================================================
+//                          Count strategies
+// ====================================================================
+
+// CountStrategy represents how Find computes the total number of rows.
+type CountStrategy int
+
+// Count strategy constants
+const (
+	// CountExact runs a second query `SELECT COUNT(*) FROM (...) _result_out_`. It is the default strategy.
+	CountExact CountStrategy = iota
+
+	// CountNone skips the count. Find returns a total of 0.
+	CountNone
+
+	// CountWindow adds a `COUNT(*) OVER()` column to the query, so that rows and total come
+	// in a single round trip (PostgreSQL, MySQL 8+, SQLite 3.25+). When the page is beyond
+	// the last row, the exact count is run.
+	CountWindow
+
+	// CountEstimate returns the estimate of the PostgreSQL planner: `pg_class.reltuples` for
+	// queries without conditions, `EXPLAIN` rows otherwise. Estimates are meant for huge tables
+	// where an approximate total is acceptable. Other dialects use the exact count.
+	CountEstimate
+)
+
+// windowCountColumn is the column of the total added by the CountWindow strategy.
+const windowCountColumn = "_result_total_"
+
+// WithoutCount skips the count query of the next Find. The returned total is 0.
+//
+// Returns:
+//   - *DBModel: A reference to the DBModel instance for chaining.
+//
+// Example:
+//
+//	var users []User
+//	_, err := mb.Instance().Where("status", mb.Eq, "active").Limit(20, 0).WithoutCount().Find(&users)
+func (db *DBModel) WithoutCount() *DBModel {
+	db.countStrategy = CountNone
+
+	return db
+}
+
+// WithCount sets how the next Find computes the total number of rows.
+//
+// Parameters:
+//   - strategy (CountStrategy): The count strategy (CountExact, CountNone, CountWindow or CountEstimate).
+//
+// Returns:
+//   - *DBModel: A reference to the DBModel instance for chaining.
+//
+// Example:
+//
+//	var users []User
+//	total, err := mb.Instance().Limit(20, 40).WithCount(mb.CountWindow).Find(&users)
+//	// Executes: SELECT *, COUNT(*) OVER() AS _result_total_ FROM users LIMIT 20 OFFSET 40
+func (db *DBModel) WithCount(strategy CountStrategy) *DBModel {
+	db.countStrategy = strategy
+
+	return db
+}
+
+// FindOnly searches for multiple rows like Find, without counting the rows matching the query.
+//
+// Parameters:
+//   - model (any): A pointer to the slice where the retrieved rows will be stored.
+//
+// Returns:
+//   - error: An error object if any issues occur during the retrieval process; nil otherwise.
+func (db *DBModel) FindOnly(model any) error {
+	_, err := db.WithoutCount().find(context.Background(), model)
+
+	return err
+}
+
+// queryWindow executes a query having the window count column and populates the model.
+// The total is read from the window count column of the first row.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the query.
+//   - sqlStr (string): The SQL query.
+//   - args ([]any): The arguments of the query.
+//   - model (any): A pointer to the slice of structs where the rows will be stored.
+//   - total (*int): The total number of rows matching the query.
+//
+// Returns:
+//   - error: An error object if any issues occur during the query; nil otherwise.
+func (db *DBModel) queryWindow(ctx context.Context, sqlStr string, args []any, model any, total *int) error {
+	sliceValue := reflect.ValueOf(model).Elem()
+	elemType := sliceValue.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+
+	structType := elemType
+	if isPtr {
+		structType = elemType.Elem()
+	}
+
+	if structType.Kind() != reflect.Struct {
+		return errors.New("Invalid data :: CountWindow requires a *Slice of structs")
+	}
+
+	rows, err := db.rowsRaw(ctx, sqlStr, args)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	// Map the columns to the fields of the struct
+	traversals := rows.Mapper.TraversalsByName(structType, columns)
+	for i, column := range columns {
+		if column != windowCountColumn && len(traversals[i]) == 0 {
+			return errors.New("Missing destination name %s in %s", column, structType)
+		}
+	}
+
+	values := make([]any, len(columns))
+
+	for rows.Next() {
+		item := reflect.New(structType)
+
+		for i, column := range columns {
+			if column == windowCountColumn {
+				values[i] = total
+			} else {
+				values[i] = reflectx.FieldByIndexes(item.Elem(), traversals[i]).Addr().Interface()
+			}
+		}
+
+		if err = rows.Scan(values...); err != nil {
+			return err
+		}
+
+		if isPtr {
+			sliceValue.Set(reflect.Append(sliceValue, item))
+		} else {
+			sliceValue.Set(reflect.Append(sliceValue, item.Elem()))
+		}
+	}
+
+	return rows.Err()
+}
+
+// estimateCount estimates the number of rows matching the query with the PostgreSQL planner.
+// The exact count is used by other dialects and when no statistics are available.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the query.
+//   - q (*qb.QueryBuilder): The query builder of the rows.
+//   - table (*Table): The table of the model.
+//   - total (*int): The estimated number of rows.
+//
+// Returns:
+//   - error: An error object if any issues occur during the query; nil otherwise.
+func (db *DBModel) estimateCount(ctx context.Context, q *qb.QueryBuilder, table *Table, total *int) error {
+	if !qb.IsDialect(qb.PostgreSQL) {
+		return db.count(ctx, q, total)
+	}
+
+	filtered := len(db.whereStatement.Conditions) > 0 || len(db.joinStatement.Items) > 0 ||
+		len(db.groupByStatement.Items) > 0 || len(db.havingStatement.Conditions) > 0
+
+	if !filtered {
+		estimate, ok, err := db.tableRowsEstimate(ctx, table)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return db.count(ctx, q, total)
+		}
+
+		*total = int(estimate)
+
+		return nil
+	}
+
+	// Rows of the query from the plan, without pagination
+	fetch := q.RemoveFetch()
+	limit := q.RemoveLimit()
+
+	sqlStr, args, _ := q.Sql()
+
+	q.Limit(limit.Limit, limit.Offset)
+	q.Fetch(fetch.Offset, fetch.Fetch)
+
+	var plan string
+	if err := db.getRawContext(ctx, "EXPLAIN (FORMAT JSON) "+sqlStr, args, &plan); err != nil {
+		return err
+	}
+
+	var explain []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+
+	if err := json.Unmarshal([]byte(plan), &explain); err != nil || len(explain) == 0 {
+		return errors.New("Invalid EXPLAIN output :: %s", plan)
+	}
+
+	*total = int(explain[0].Plan.Rows)
+
+	return nil
+}
+
+// tableRowsEstimate returns the number of rows of a PostgreSQL table from its statistics.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the query.
+//   - table (*Table): The table of the model.
+//
+// Returns:
+//   - float64: The estimated number of rows.
+//   - bool: False when the table is unknown or was never analyzed.
+//   - error: An error object if any issues occur during the query; nil otherwise.
+func (db *DBModel) tableRowsEstimate(ctx context.Context, table *Table) (float64, bool, error) {
+	// The value is -1 when the table was never analyzed
+	var estimate float64
+
+	err := db.getRawContext(ctx, "SELECT reltuples FROM pg_class WHERE oid = to_regclass($1)",
+		[]any{table.Name}, &estimate)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+
+	if err != nil {
+		return 0, false, err
+	}
+
+	return estimate, estimate >= 0, nil
+}
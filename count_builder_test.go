@@ -0,0 +1,181 @@
+package db
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+
+	qb "github.com/jivegroup/fluentsql"
+)
+
+func TestCountStrategies(t *testing.T) {
+	tests := []struct {
+		name          string
+		dialect       qb.Dialect
+		find          func(db *DBModel, posts *[]unionPost) (int, error)
+		results       []fakeResult
+		expected      []string
+		expectedTotal int
+		expectedPosts []unionPost
+	}{
+		{
+			name:    "WithoutCount",
+			dialect: new(qb.PostgreSQLDialect),
+			find: func(db *DBModel, posts *[]unionPost) (int, error) {
+				return db.WithoutCount().Find(posts)
+			},
+			results: []fakeResult{
+				{columns: []string{"id", "title"}, rows: [][]driver.Value{{int64(1), "a"}}},
+			},
+			expected:      []string{"SELECT * FROM posts"},
+			expectedTotal: 0,
+			expectedPosts: []unionPost{{ID: 1, Title: "a"}},
+		},
+		{
+			name:    "FindOnly",
+			dialect: new(qb.PostgreSQLDialect),
+			find: func(db *DBModel, posts *[]unionPost) (int, error) {
+				return 0, db.Where("id", Greater, 0).FindOnly(posts)
+			},
+			results: []fakeResult{
+				{columns: []string{"id", "title"}, rows: [][]driver.Value{{int64(1), "a"}}},
+			},
+			expected:      []string{"SELECT * FROM posts WHERE id > $1"},
+			expectedPosts: []unionPost{{ID: 1, Title: "a"}},
+		},
+		{
+			name:    "CountWindow",
+			dialect: new(qb.PostgreSQLDialect),
+			find: func(db *DBModel, posts *[]unionPost) (int, error) {
+				return db.Limit(2, 0).WithCount(CountWindow).Find(posts)
+			},
+			results: []fakeResult{
+				{
+					columns: []string{"id", "title", windowCountColumn},
+					rows:    [][]driver.Value{{int64(1), "a", int64(7)}, {int64(2), "b", int64(7)}},
+				},
+			},
+			expected:      []string{"SELECT *, COUNT(*) OVER() AS _result_total_ FROM posts LIMIT $1 OFFSET $2"},
+			expectedTotal: 7,
+			expectedPosts: []unionPost{{ID: 1, Title: "a"}, {ID: 2, Title: "b"}},
+		},
+		{
+			name:    "CountWindow beyond the last row",
+			dialect: new(qb.PostgreSQLDialect),
+			find: func(db *DBModel, posts *[]unionPost) (int, error) {
+				return db.Limit(2, 10).WithCount(CountWindow).Find(posts)
+			},
+			results: []fakeResult{
+				{columns: []string{"id", "title", windowCountColumn}},
+				{columns: []string{"total"}, rows: [][]driver.Value{{int64(7)}}},
+			},
+			expected: []string{
+				"SELECT *, COUNT(*) OVER() AS _result_total_ FROM posts LIMIT $1 OFFSET $2",
+				"SELECT COUNT(*) AS total FROM (SELECT *, COUNT(*) OVER() AS _result_total_ FROM posts) _result_out_",
+			},
+			expectedTotal: 7,
+		},
+		{
+			name:    "CountEstimate of a table",
+			dialect: new(qb.PostgreSQLDialect),
+			find: func(db *DBModel, posts *[]unionPost) (int, error) {
+				return db.WithCount(CountEstimate).Find(posts)
+			},
+			results: []fakeResult{
+				{columns: []string{"id", "title"}},
+				{columns: []string{"reltuples"}, rows: [][]driver.Value{{1234.0}}},
+			},
+			expected: []string{
+				"SELECT * FROM posts",
+				"SELECT reltuples FROM pg_class WHERE oid = to_regclass($1)",
+			},
+			expectedTotal: 1234,
+		},
+		{
+			name:    "CountEstimate of a table never analyzed",
+			dialect: new(qb.PostgreSQLDialect),
+			find: func(db *DBModel, posts *[]unionPost) (int, error) {
+				return db.WithCount(CountEstimate).Find(posts)
+			},
+			results: []fakeResult{
+				{columns: []string{"id", "title"}},
+				{columns: []string{"reltuples"}, rows: [][]driver.Value{{-1.0}}},
+				{columns: []string{"total"}, rows: [][]driver.Value{{int64(3)}}},
+			},
+			expected: []string{
+				"SELECT * FROM posts",
+				"SELECT reltuples FROM pg_class WHERE oid = to_regclass($1)",
+				"SELECT COUNT(*) AS total FROM (SELECT * FROM posts) _result_out_",
+			},
+			expectedTotal: 3,
+		},
+		{
+			name:    "CountEstimate of a query",
+			dialect: new(qb.PostgreSQLDialect),
+			find: func(db *DBModel, posts *[]unionPost) (int, error) {
+				return db.Where("title", Like, "%go%").Limit(10, 0).WithCount(CountEstimate).Find(posts)
+			},
+			results: []fakeResult{
+				{columns: []string{"id", "title"}},
+				{columns: []string{"plan"}, rows: [][]driver.Value{{`[{"Plan": {"Node Type": "Seq Scan", "Plan Rows": 42}}]`}}},
+			},
+			expected: []string{
+				"SELECT * FROM posts WHERE title LIKE $1 LIMIT $2 OFFSET $3",
+				"EXPLAIN (FORMAT JSON) SELECT * FROM posts WHERE title LIKE $1",
+			},
+			expectedTotal: 42,
+		},
+		{
+			name:    "CountEstimate of another dialect",
+			dialect: new(qb.MySQLDialect),
+			find: func(db *DBModel, posts *[]unionPost) (int, error) {
+				return db.WithCount(CountEstimate).Find(posts)
+			},
+			results: []fakeResult{
+				{columns: []string{"id", "title"}},
+				{columns: []string{"total"}, rows: [][]driver.Value{{int64(5)}}},
+			},
+			expected: []string{
+				"SELECT * FROM posts",
+				"SELECT COUNT(*) AS total FROM (SELECT * FROM posts) _result_out_",
+			},
+			expectedTotal: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeDB(t, tt.dialect, tt.results...)
+
+			var posts []unionPost
+			total, err := tt.find(Instance(), &posts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if total != tt.expectedTotal {
+				t.Errorf("total = %d, expected %d", total, tt.expectedTotal)
+			}
+
+			if !reflect.DeepEqual(posts, tt.expectedPosts) {
+				t.Errorf("posts = %+v, expected %+v", posts, tt.expectedPosts)
+			}
+
+			if !reflect.DeepEqual(fake.statements, tt.expected) {
+				t.Errorf("statements = %q, expected %q", fake.statements, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCountWindowUnknownColumn(t *testing.T) {
+	useFakeDB(t, new(qb.PostgreSQLDialect), fakeResult{
+		columns: []string{"id", "unknown", windowCountColumn},
+		rows:    [][]driver.Value{{int64(1), "a", int64(1)}},
+	})
+
+	var posts []unionPost
+	if _, err := Instance().WithCount(CountWindow).Find(&posts); err == nil {
+		t.Error("Find() expected an error for a column without field")
+	}
+}
@@ -1,11 +1,13 @@
 package db
 
 import (
+	"fmt"
 	"github.com/gflydev/core/errors"
 	"github.com/gflydev/core/log"
 	qb "github.com/jivegroup/fluentsql"
 	"reflect"
 	"slices"
+	"strings"
 )
 
 // Create inserts new data into a database table using various model types.
@@ -18,7 +20,7 @@ import (
 //   - model (any): The data to be inserted. Supported types:
 //   - Raw SQL: When db.raw.sqlStr is set, uses raw SQL insertion
 //   - map[string]any: Creates a record using key-value pairs from the map
-//   - []Struct or []*Struct: Batch insertion of multiple records
+//   - []Struct, []*Struct or a pointer to them: Batch insertion of multiple records (see CreateInBatches)
 //   - Struct or *Struct: Single record insertion using struct fields
 //
 // Returns:
@@ -56,7 +58,7 @@ import (
 // Note:
 //   - Primary key fields are automatically handled and populated after insertion
 //   - The method respects Select() and Omit() clauses for column filtering
-//   - For batch operations, if one record fails, the entire operation may fail
+//   - Slices are inserted with multi-row INSERT statements of DefaultCreateBatchSize rows
 //   - The model is reset after the operation completes
 func (db *DBModel) Create(model any) (err error) {
 	// Get the type of the model
@@ -66,10 +68,12 @@ func (db *DBModel) Create(model any) (err error) {
 	switch {
 	case db.raw.sqlStr != "":
 		err = db.createByRaw(model)
+	case typ.Kind() == reflect.Map && db.model == nil && db.table != "":
+		err = db.createByTable(model)
 	case typ.Kind() == reflect.Map:
 		err = db.createByMap(model)
-	case typ.Kind() == reflect.Slice:
-		err = db.createBySlice(model)
+	case typ.Kind() == reflect.Slice || (typ.Kind() == reflect.Ptr && typ.Elem().Kind() == reflect.Slice):
+		err = db.createBySlice(model, DefaultCreateBatchSize)
 	case
 		typ.Kind() == reflect.Struct || (typ.Kind() == reflect.Ptr && typ.Elem().Kind() == reflect.Struct):
 		err = db.createByStruct(model)
@@ -85,6 +89,45 @@ func (db *DBModel) Create(model any) (err error) {
 	return
 }
 
+// DefaultCreateBatchSize is the number of rows per INSERT statement when creating a slice.
+const DefaultCreateBatchSize = 100
+
+// CreateInBatches inserts the models of a slice with multi-row INSERT statements of batchSize rows.
+// Serial primary keys are set back on the elements of the slice. See Create.
+//
+// Parameters:
+//   - model (any): A slice or a pointer to a slice of structs or pointers to structs.
+//   - batchSize (int): The number of rows per INSERT statement.
+//
+// Returns:
+//   - error: A BatchError reporting the error of every failed batch, nil if all batches succeed.
+//
+// Example:
+//
+//	err := db.CreateInBatches(users, 500)
+func (db *DBModel) CreateInBatches(model any, batchSize int) (err error) {
+	defer db.reset()
+
+	typ := reflect.TypeOf(model)
+	if typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Slice {
+		return errors.New("Invalid data :: model not Slice type")
+	}
+
+	if batchSize < 1 {
+		return errors.New("Invalid batch size %d :: batch size must be greater than 0", batchSize)
+	}
+
+	if err = db.createBySlice(model, batchSize); err != nil {
+		log.Error(err)
+	}
+
+	return
+}
+
 // createByRaw executes a raw SQL insertion query with automatic primary key handling.
 // This method is used internally when a raw SQL string has been set via the Raw() method.
 // It extracts table metadata from the provided model to handle primary key population
@@ -242,25 +285,20 @@ func (db *DBModel) createByMap(value any) error {
 }
 
 // createBySlice performs batch insertion of multiple records from a slice of models.
-// This method iterates through each element in the provided slice and inserts them
-// individually using createByStruct. It supports both struct values and pointers to structs,
-// providing flexibility for different data structures. The method handles type validation
-// and skips invalid entries to ensure robust batch processing.
+// The records are inserted with multi-row INSERT statements of batchSize rows, e.g.
+// `INSERT INTO users (name, email) VALUES ($1, $2), ($3, $4)`. Serial primary keys are set back
+// on each element: via RETURNING for PostgreSQL and SQLite, and from the consecutive IDs following
+// LastInsertId for MySQL. The slice elements are modified even when the slice is passed by value.
 //
 // Parameters:
-//   - model (any): A slice containing the models to insert. Supported slice types:
+//   - model (any): A slice or a pointer to a slice containing the models to insert. Supported slice types:
 //   - []Struct: Slice of struct values (e.g., []User)
 //   - []*Struct: Slice of pointers to structs (e.g., []*User)
-//   - Mixed slices are supported, but each element must be a valid struct or pointer to struct
-//   - Invalid or nil elements in the slice are automatically skipped
+//   - Nil elements in the slice are skipped
+//   - batchSize (int): The number of rows per INSERT statement.
 //
 // Returns:
-//   - error: Returns an error if:
-//   - Any individual record insertion fails (the error from the last failed insertion)
-//   - The model parameter is not a valid slice type
-//   - Database connectivity issues occur during any insertion
-//   - Struct reflection fails for any element
-//     Returns nil if all insertions succeed or if all elements are skipped.
+//   - error: A BatchError reporting the error of every failed batch, nil if all batches succeed.
 //
 // Examples:
 //
@@ -268,65 +306,189 @@ func (db *DBModel) createByMap(value any) error {
 //	users := []User{
 //	    {Name: "Alice", Email: "alice@example.com"},
 //	    {Name: "Bob", Email: "bob@example.com"},
-//	    {Name: "Charlie", Email: "charlie@example.com"},
 //	}
 //	err := db.Model(&User{}).Create(users)
+//	// Executes: INSERT INTO users (name, email) VALUES ($1, $2), ($3, $4) RETURNING id
+//	// users[0].ID and users[1].ID are set
 //
-//	// Batch insertion with pointer slice
-//	users := []*User{
-//	    &User{Name: "David", Email: "david@example.com"},
-//	    &User{Name: "Eve", Email: "eve@example.com"},
-//	}
-//	err := db.Model(&User{}).Create(users)
-//
-//	// Mixed slice with some nil pointers (nil entries will be skipped)
-//	users := []*User{
-//	    &User{Name: "Frank", Email: "frank@example.com"},
-//	    nil, // This will be skipped
-//	    &User{Name: "Grace", Email: "grace@example.com"},
-//	}
-//	err := db.Model(&User{}).Create(users)
-//
-//	// Empty slice (no operation performed)
-//	var users []User
-//	err := db.Model(&User{}).Create(users) // Returns nil, no insertions
+//	// Batches of 500 rows
+//	err := db.CreateInBatches(users, 500)
 //
 // Note:
-//   - Each record is inserted individually, not as a single batch SQL operation
-//   - If one insertion fails, subsequent insertions are not attempted
-//   - Primary keys are populated for each successfully inserted record
+//   - Like a single Create, zero fields are not inserted so that the columns take their default.
+//     The rows are grouped by their non-zero columns, and each group is inserted with its own statements
+//   - A failed batch doesn't stop the next batches. Use a transaction to insert all rows or none
 //   - The method respects Select() and Omit() clauses for all insertions
-//   - Invalid or nil slice elements are silently skipped
-//   - For better performance with large datasets, consider using database-specific batch insert methods
-func (db *DBModel) createBySlice(model any) (err error) {
+//   - MySQL IDs are consecutive with innodb_autoinc_lock_mode 0 or 1 and auto_increment_increment = 1.
+//     IDs are not set back for upserts (OnConflict) on MySQL, nor when rows are skipped on PostgreSQL
+func (db *DBModel) createBySlice(model any, batchSize int) error {
 	// Reflect the Slice
-	items := reflect.ValueOf(model)
+	items := reflect.Indirect(reflect.ValueOf(model))
+
+	var batchError BatchError
+
+	// Rows having the same non-zero columns, in order of appearance
+	type group struct {
+		columns   []string
+		rows      []any    // Pointers to the elements to insert
+		tables    []*Table // Records of the elements
+		positions []int    // Positions of the elements in the slice
+	}
+
+	var groups []*group
+	groupsByColumns := make(map[string]*group)
 
-	// Iterate through each element in the Slice
 	for i := 0; i < items.Len(); i++ {
 		itemVal := items.Index(i)
-		var indirectVal reflect.Value
+
+		var row any
 
 		// Handle *Struct or Struct types
-		if itemVal.Kind() == reflect.Pointer {
-			indirectVal = reflect.Indirect(itemVal.Elem())
-		} else if itemVal.Kind() == reflect.Struct {
-			indirectVal = reflect.Indirect(itemVal)
+		switch {
+		case itemVal.Kind() == reflect.Pointer && !itemVal.IsNil() && itemVal.Elem().Kind() == reflect.Struct:
+			row = itemVal.Interface()
+		case itemVal.Kind() == reflect.Struct:
+			row = itemVal.Addr().Interface()
+		default:
+			// Skip invalid types
+			continue
 		}
 
-		// Skip invalid types
-		if !indirectVal.IsValid() {
+		// Create a table object from a model
+		table, err := CreateData(row)
+		if err != nil {
+			batchError.Errors = append(batchError.Errors, fmt.Errorf("row %d: %w", i, err))
+
 			continue
 		}
 
-		// Perform insertion for each item in the Slice
-		item := itemVal.Interface()
-		err = db.createByStruct(item)
+		columns := db.insertColumns(table)
+		key := strings.Join(columns, ",")
+
+		rowGroup, ok := groupsByColumns[key]
+		if !ok {
+			rowGroup = &group{columns: columns}
+			groupsByColumns[key] = rowGroup
+			groups = append(groups, rowGroup)
+		}
+
+		rowGroup.rows = append(rowGroup.rows, row)
+		rowGroup.tables = append(rowGroup.tables, table)
+		rowGroup.positions = append(rowGroup.positions, i)
+	}
+
+	for _, rowGroup := range groups {
+		for start := 0; start < len(rowGroup.rows); start += batchSize {
+			end := min(start+batchSize, len(rowGroup.rows))
+
+			if err := db.createBatch(rowGroup.rows[start:end], rowGroup.tables[start:end], rowGroup.columns); err != nil {
+				batchError.Errors = append(batchError.Errors,
+					fmt.Errorf("rows %v: %w", rowGroup.positions[start:end], err))
+			}
+		}
+	}
+
+	if len(batchError.Errors) > 0 {
+		return batchError
 	}
 
+	return nil
+}
+
+// createBatch inserts the records with a single multi-row INSERT statement and sets their serial primary keys.
+//
+// Parameters:
+//   - rows ([]any): Pointers to the structs to insert. They have the same type.
+//   - tables ([]*Table): The records of the structs (see CreateData).
+//   - columns ([]string): The inserted columns, having a value in every row.
+//
+// Returns:
+//   - error: An error object if any issues occur during the insertion; nil otherwise.
+func (db *DBModel) createBatch(rows []any, tables []*Table, columns []string) (err error) {
+	table := tables[0]
+	db.applyTable(table)
+
+	// Serial keys are generated unless the rows have one
+	var primaryColumn = table.PrimarySerial
+	if primaryColumn != nil && slices.Contains(columns, primaryColumn.Name) {
+		primaryColumn = nil
+	}
+
+	// Build a multi-row INSERT SQL statement
+	insertBuilder := qb.InsertInstance().
+		Insert(table.Name, columns...)
+
+	for _, rowTable := range tables {
+		values := make([]any, len(columns))
+		for i, column := range columns {
+			values[i] = rowTable.Values[column]
+		}
+
+		insertBuilder.Row(values...)
+	}
+
+	sqlStr, args, _ := insertBuilder.Sql()
+
+	// Append the clause handling unique conflicts (upsert)
+	if db.conflict != nil {
+		if sqlStr, err = db.conflict.apply(sqlStr, table, columns); err != nil {
+			return
+		}
+	}
+
+	// Perform the insert and retrieve the IDs
+	var ids []any
+	if ids, err = db.addRows(sqlStr, args, primaryColumn, len(rows)); err != nil {
+		return
+	}
+
+	// Set the IDs back to the models when they match the rows
+	if primaryColumn != nil && len(ids) == len(rows) {
+		for i, row := range rows {
+			if err = setValue(row, primaryColumn.Key, ids[i]); err != nil {
+				return
+			}
+		}
+	}
+
+	// Keep the written values of models tracking their changes
+	snapshotModels(rows)
+
 	return
 }
 
+// insertColumns returns the columns of a record to insert: the data columns having a non-zero value,
+// restricted by the Select() and Omit() clauses.
+//
+// Parameters:
+//   - table (*Table): The record to insert.
+//
+// Returns:
+//   - []string: The names of the inserted columns, in field order.
+func (db *DBModel) insertColumns(table *Table) []string {
+	var columns []string
+
+	for _, column := range table.Columns {
+		if column.isNotData() || column.IsZero {
+			continue
+		}
+
+		// Skip columns not included in the select statement
+		if len(db.selectStatement.Columns) > 0 && !slices.Contains(db.selectStatement.Columns, any(column.Name)) {
+			continue
+		}
+
+		// Skip columns specified in the omits select statement
+		if len(db.omitsSelectStatement.Columns) > 0 && slices.Contains(db.omitsSelectStatement.Columns, any(column.Name)) {
+			continue
+		}
+
+		columns = append(columns, column.Name)
+	}
+
+	return columns
+}
+
 // createByStruct inserts a single database record using struct field reflection.
 // This is the core insertion method that handles individual struct-based insertions.
 // It extracts table metadata from the struct, builds appropriate SQL INSERT statements,
@@ -394,30 +556,15 @@ func (db *DBModel) createByStruct(model any) (err error) {
 	var values []any
 
 	// Create a table object from a model
-	if table, err = ModelData(model); err != nil {
+	if table, err = CreateData(model); err != nil {
 		return
 	}
+	db.applyTable(table)
 
-	// Generate insert columns and values by iterating over table columns
-	for _, column := range table.Columns {
-		if column.isNotData() || column.IsZero {
-			continue
-		}
-
-		// Skip columns not included in the select statement
-		if len(db.selectStatement.Columns) > 0 && !slices.Contains(db.selectStatement.Columns, any(column.Name)) {
-			continue
-		}
-
-		// Skip columns specified in the omits select statement
-		if len(db.omitsSelectStatement.Columns) > 0 && slices.Contains(db.omitsSelectStatement.Columns, any(column.Name)) {
-			continue
-		}
-
-		// Add column names and values to their respective slices
-		value := table.Values[column.Name]
-		columns = append(columns, column.Name)
-		values = append(values, value)
+	// Generate insert columns and values
+	columns = db.insertColumns(table)
+	for _, column := range columns {
+		values = append(values, table.Values[column])
 	}
 
 	// Build an INSERT SQL statement with the columns and values
@@ -428,15 +575,29 @@ func (db *DBModel) createByStruct(model any) (err error) {
 	var id any
 	var primaryColumn = table.PrimarySerial
 
+	sqlStr, args, _ := insertBuilder.Sql()
+
+	// Append the clause handling unique conflicts (upsert)
+	if db.conflict != nil {
+		if sqlStr, err = db.conflict.apply(sqlStr, table, columns); err != nil {
+			return
+		}
+	}
+
 	// Perform the insert and Try to retrieve the ID
-	if id, err = db.add(insertBuilder, primaryColumn); err != nil {
+	if id, err = db.addRaw(sqlStr, args, primaryColumn); err != nil {
 		return
 	}
 
-	// Set the ID back to the model
-	if primaryColumn != nil {
-		err = setValue(model, primaryColumn.Key, id)
+	// Set the ID back to the model (no ID when a conflicting row is skipped)
+	if primaryColumn != nil && id != nil {
+		if err = setValue(model, primaryColumn.Key, id); err != nil {
+			return
+		}
 	}
 
+	// Keep the written values of models tracking their changes
+	snapshotModels(model)
+
 	return
 }
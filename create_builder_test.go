@@ -0,0 +1,288 @@
+package db
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	qb "github.com/jivegroup/fluentsql"
+)
+
+type createUser struct {
+	MetaData MetaData `db:"-" model:"table:users"`
+	ID       int      `db:"id" model:"name:id; type:serial,primary"`
+	Email    string   `db:"email" model:"name:email"`
+	Status   string   `db:"status" model:"name:status"` // Defaults to 'pending' in the database
+}
+
+func TestCreateSetsID(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  qb.Dialect
+		result   fakeResult
+		expected string
+	}{
+		{
+			name:     "PostgreSQL",
+			dialect:  new(qb.PostgreSQLDialect),
+			result:   fakeResult{columns: []string{"id"}, rows: [][]driver.Value{{int64(5)}}},
+			expected: "INSERT INTO users (email) VALUES ($1) RETURNING id",
+		},
+		{
+			name:     "SQLite",
+			dialect:  new(qb.SQLiteDialect),
+			result:   fakeResult{columns: []string{"id"}, rows: [][]driver.Value{{int64(5)}}},
+			expected: "INSERT INTO users (email) VALUES (?) RETURNING id",
+		},
+		{
+			name:     "MySQL",
+			dialect:  new(qb.MySQLDialect),
+			result:   fakeResult{affected: 1, lastInsertID: 5},
+			expected: "INSERT INTO users (email) VALUES (?)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeDB(t, tt.dialect, tt.result)
+
+			db := Instance()
+			user := createUser{Email: "john@gfly.dev"}
+			if err := db.Create(&user); err != nil {
+				t.Fatal(err)
+			}
+
+			if user.ID != 5 {
+				t.Errorf("user.ID = %d, expected 5", user.ID)
+			}
+
+			if db.RowsAffected() != 1 {
+				t.Errorf("RowsAffected() = %d, expected 1", db.RowsAffected())
+			}
+
+			if len(fake.statements) != 1 || fake.statements[0] != tt.expected {
+				t.Errorf("statements = %q, expected %q", fake.statements, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCreateFailure(t *testing.T) {
+	errInsert := errors.New("duplicate key")
+
+	for _, dialect := range []qb.Dialect{new(qb.PostgreSQLDialect), new(qb.SQLiteDialect), new(qb.MySQLDialect)} {
+		t.Run(dialect.Name(), func(t *testing.T) {
+			useFakeDB(t, dialect,
+				fakeResult{columns: []string{"id"}, rows: [][]driver.Value{{int64(1)}}, affected: 1, lastInsertID: 1},
+				fakeResult{err: errInsert},
+			)
+
+			db := Instance()
+			if err := db.Create(&createUser{Email: "a@gfly.dev"}); err != nil {
+				t.Fatal(err)
+			}
+
+			user := createUser{Email: "a@gfly.dev"}
+			if err := db.Create(&user); !errors.Is(err, errInsert) {
+				t.Fatalf("Create() = %v, expected %v", err, errInsert)
+			}
+
+			if db.RowsAffected() != 0 {
+				t.Errorf("RowsAffected() = %d, expected 0 after a failed insert", db.RowsAffected())
+			}
+
+			if user.ID != 0 {
+				t.Errorf("user.ID = %d, expected 0", user.ID)
+			}
+		})
+	}
+}
+
+func TestCreateSlice(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  qb.Dialect
+		create   func(db *DBModel, users []createUser) error
+		results  []fakeResult
+		expected []string
+		ids      []int
+	}{
+		{
+			name:    "PostgreSQL",
+			dialect: new(qb.PostgreSQLDialect),
+			create: func(db *DBModel, users []createUser) error {
+				return db.Create(users)
+			},
+			results: []fakeResult{
+				{columns: []string{"id"}, rows: [][]driver.Value{{int64(1)}, {int64(3)}}},
+				{columns: []string{"id"}, rows: [][]driver.Value{{int64(2)}}},
+			},
+			// Zero fields are not inserted, so that the rows without status take the column default
+			expected: []string{
+				"INSERT INTO users (email) VALUES ($1), ($2) RETURNING id",
+				"INSERT INTO users (email, status) VALUES ($1, $2) RETURNING id",
+			},
+			ids: []int{1, 2, 3},
+		},
+		{
+			name:    "SQLite",
+			dialect: new(qb.SQLiteDialect),
+			create: func(db *DBModel, users []createUser) error {
+				return db.Create(users)
+			},
+			results: []fakeResult{
+				{columns: []string{"id"}, rows: [][]driver.Value{{int64(1)}, {int64(3)}}},
+				{columns: []string{"id"}, rows: [][]driver.Value{{int64(2)}}},
+			},
+			expected: []string{
+				"INSERT INTO users (email) VALUES (?), (?) RETURNING id",
+				"INSERT INTO users (email, status) VALUES (?, ?) RETURNING id",
+			},
+			ids: []int{1, 2, 3},
+		},
+		{
+			name:    "MySQL pointer to slice",
+			dialect: new(qb.MySQLDialect),
+			create: func(db *DBModel, users []createUser) error {
+				return db.Create(&users)
+			},
+			results: []fakeResult{
+				{affected: 2, lastInsertID: 10},
+				{affected: 1, lastInsertID: 12},
+			},
+			expected: []string{
+				"INSERT INTO users (email) VALUES (?), (?)",
+				"INSERT INTO users (email, status) VALUES (?, ?)",
+			},
+			ids: []int{10, 12, 11},
+		},
+		{
+			name:    "batches of one group",
+			dialect: new(qb.PostgreSQLDialect),
+			create: func(db *DBModel, users []createUser) error {
+				return db.CreateInBatches(users, 1)
+			},
+			results: []fakeResult{
+				{columns: []string{"id"}, rows: [][]driver.Value{{int64(1)}}},
+				{columns: []string{"id"}, rows: [][]driver.Value{{int64(3)}}},
+				{columns: []string{"id"}, rows: [][]driver.Value{{int64(2)}}},
+			},
+			expected: []string{
+				"INSERT INTO users (email) VALUES ($1) RETURNING id",
+				"INSERT INTO users (email) VALUES ($1) RETURNING id",
+				"INSERT INTO users (email, status) VALUES ($1, $2) RETURNING id",
+			},
+			ids: []int{1, 2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeDB(t, tt.dialect, tt.results...)
+
+			users := []createUser{
+				{Email: "a@gfly.dev"},
+				{Email: "b@gfly.dev", Status: "active"},
+				{Email: "c@gfly.dev"},
+			}
+			if err := tt.create(Instance(), users); err != nil {
+				t.Fatal(err)
+			}
+
+			ids := make([]int, len(users))
+			for i, user := range users {
+				ids[i] = user.ID
+			}
+
+			if !reflect.DeepEqual(ids, tt.ids) {
+				t.Errorf("ids = %v, expected %v", ids, tt.ids)
+			}
+
+			if !reflect.DeepEqual(fake.statements, tt.expected) {
+				t.Errorf("statements = %q, expected %q", fake.statements, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCreateSliceWithIDs(t *testing.T) {
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect),
+		fakeResult{affected: 1},
+		fakeResult{columns: []string{"id"}, rows: [][]driver.Value{{int64(8)}}},
+	)
+
+	// Rows with a key insert it, the others get a generated key
+	users := []*createUser{{ID: 7, Email: "a@gfly.dev"}, nil, {Email: "b@gfly.dev"}}
+	if err := Instance().Create(users); err != nil {
+		t.Fatal(err)
+	}
+
+	if users[0].ID != 7 || users[2].ID != 8 {
+		t.Errorf("ids = %d, %d, expected 7, 8", users[0].ID, users[2].ID)
+	}
+
+	expected := []string{
+		"INSERT INTO users (id, email) VALUES ($1, $2)",
+		"INSERT INTO users (email) VALUES ($1) RETURNING id",
+	}
+	if !reflect.DeepEqual(fake.statements, expected) {
+		t.Errorf("statements = %q, expected %q", fake.statements, expected)
+	}
+}
+
+func TestCreateInBatchesError(t *testing.T) {
+	errInsert := errors.New("insert failed")
+
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect),
+		fakeResult{err: errInsert},
+		fakeResult{columns: []string{"id"}, rows: [][]driver.Value{{int64(2)}}},
+		fakeResult{err: errInsert},
+	)
+
+	users := []createUser{{Email: "a@gfly.dev"}, {Email: "b@gfly.dev"}, {Email: "c@gfly.dev"}}
+	err := Instance().CreateInBatches(users, 1)
+
+	// A failed batch doesn't stop the next batches
+	if len(fake.statements) != 3 {
+		t.Errorf("statements = %q, expected 3", fake.statements)
+	}
+
+	var batchError BatchError
+	if !errors.As(err, &batchError) {
+		t.Fatalf("CreateInBatches() = %v, expected a BatchError", err)
+	}
+
+	if len(batchError.Errors) != 2 {
+		t.Errorf("Errors = %v, expected 2 errors", batchError.Errors)
+	}
+
+	if !errors.Is(err, errInsert) {
+		t.Errorf("errors.Is(%v, errInsert) = false, expected true", err)
+	}
+
+	if !strings.Contains(err.Error(), "rows [0]") || !strings.Contains(err.Error(), "rows [2]") {
+		t.Errorf("Error() = %q, expected the positions of the failed rows", err.Error())
+	}
+
+	if users[1].ID != 2 || users[0].ID != 0 || users[2].ID != 0 {
+		t.Errorf("ids = %d, %d, %d, expected 0, 2, 0", users[0].ID, users[1].ID, users[2].ID)
+	}
+}
+
+func TestCreateInBatchesInvalid(t *testing.T) {
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect))
+
+	if err := Instance().CreateInBatches(createUser{}, 10); err == nil {
+		t.Error("CreateInBatches() of a struct expected an error")
+	}
+
+	if err := Instance().CreateInBatches([]createUser{{}}, 0); err == nil {
+		t.Error("CreateInBatches() with batch size 0 expected an error")
+	}
+
+	if len(fake.statements) != 0 {
+		t.Errorf("statements = %q, expected none", fake.statements)
+	}
+}
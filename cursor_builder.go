@@ -0,0 +1,644 @@
+package db
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/core/utils"
+	qb "github.com/jivegroup/fluentsql"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ====================================================================
+//                        Cursor pagination
+// ====================================================================
+
+var (
+	// cursorSecret is the HMAC key signing the pagination cursors. It is read from
+	// DB_CURSOR_SECRET on first use unless SetCursorSecret was called.
+	cursorSecret []byte
+
+	// cursorSecretMutex guards cursorSecret.
+	cursorSecretMutex sync.RWMutex
+)
+
+// SetCursorSecret sets the key signing the pagination cursors of CursorPaginate.
+// By default, the key is read from the DB_CURSOR_SECRET environment variable when the first
+// cursor is signed or verified, so that it can be loaded from a .env file at startup.
+// Every instance sharing cursors must use the same key.
+//
+// Parameters:
+//   - secret ([]byte): The HMAC-SHA256 key. At least 32 bytes are recommended. Empty reads DB_CURSOR_SECRET again.
+func SetCursorSecret(secret []byte) {
+	cursorSecretMutex.Lock()
+	defer cursorSecretMutex.Unlock()
+
+	cursorSecret = append([]byte(nil), secret...)
+}
+
+// cursorSecretKey returns the key signing the pagination cursors.
+//
+// Returns:
+//   - []byte: The HMAC-SHA256 key.
+//   - error: ErrMissingCursorSecret if neither SetCursorSecret nor DB_CURSOR_SECRET gives a key.
+func cursorSecretKey() ([]byte, error) {
+	cursorSecretMutex.RLock()
+	secret := cursorSecret
+	cursorSecretMutex.RUnlock()
+
+	if len(secret) > 0 {
+		return secret, nil
+	}
+
+	cursorSecretMutex.Lock()
+	defer cursorSecretMutex.Unlock()
+
+	if len(cursorSecret) == 0 {
+		cursorSecret = []byte(utils.Getenv("DB_CURSOR_SECRET", ""))
+	}
+
+	if len(cursorSecret) == 0 {
+		return nil, ErrMissingCursorSecret
+	}
+
+	return cursorSecret, nil
+}
+
+// CursorPagination represents the cursors of a page retrieved by keyset pagination.
+//
+// Fields:
+//   - Next (string): The cursor of the next page. Empty on the last page.
+//   - Prev (string): The cursor of the previous page. Empty on the first page.
+//   - PerPage (int): The number of items per page, after capping.
+type CursorPagination struct {
+	Next    string `json:"next"`
+	Prev    string `json:"prev"`
+	PerPage int    `json:"perPage"`
+}
+
+// CursorPage represents a page of items retrieved by keyset pagination.
+//
+// Fields:
+//   - Items ([]T): The items of the page. Never nil.
+//   - CursorPagination: The cursors of the page.
+type CursorPage[T any] struct {
+	Items []T `json:"items"`
+	CursorPagination
+}
+
+// cursorDirection represents the direction of a cursor.
+type cursorDirection string
+
+// Cursor direction constants
+const (
+	cursorNext cursorDirection = "n" // Rows after the cursor
+	cursorPrev cursorDirection = "p" // Rows before the cursor
+)
+
+// cursorKey represents a sort column of keyset pagination.
+type cursorKey struct {
+	column string // The column as written in the query (e.g. "users.created_at")
+	name   string // The column name in the model (e.g. "created_at")
+	desc   bool   // Whether the column is sorted in descending order
+}
+
+// cursorData represents the content of a cursor.
+type cursorData struct {
+	Direction cursorDirection `json:"d"`
+	Columns   []string        `json:"c"`
+	Values    [][2]string     `json:"v"`
+}
+
+// CursorPaginate retrieves a page of rows using keyset pagination.
+// The sort columns are the ORDER BY items followed by the primary key, which makes the order
+// unique. Rows are selected by comparing the sort columns with the values of the last row of
+// the previous page, so that deep pages are as fast as the first one when an index exists on
+// the sort columns. Sort columns must not be NULL.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the query.
+//   - cursor (string): The cursor returned by a previous call (Next or Prev). Empty for the first page.
+//   - size (int): The number of items per page. It is capped by SetMaxPerPage (default 100).
+//   - items (any): A pointer to the slice where the rows of the page will be stored.
+//
+// Returns:
+//   - CursorPagination: The cursors of the previous and next pages.
+//   - error: ErrInvalidCursor if the cursor is invalid, ErrMissingCursorSecret if no key signs the cursors,
+//     or an error if the query fails.
+//
+// Example:
+//
+//	var users []User
+//	pagination, err := mb.Instance().Where("status", mb.Eq, "active").
+//	    OrderBy("created_at", mb.Desc).
+//	    CursorPaginate(ctx, request.Cursor, 20, &users)
+//	// Next page: SELECT * FROM users WHERE status = $1 AND (created_at, users.id) < ($2, $3)
+//	//            ORDER BY created_at DESC, users.id DESC LIMIT 21 OFFSET 0
+func (db *DBModel) CursorPaginate(ctx context.Context, cursor string, size int, items any) (pagination CursorPagination, err error) {
+	defer db.reset()
+
+	typ := reflect.TypeOf(items)
+	if typ == nil || !(typ.Kind() == reflect.Ptr && typ.Elem().Kind() == reflect.Slice) {
+		return pagination, errors.New("Invalid data :: items not *Slice type")
+	}
+
+	if size < 1 {
+		return pagination, errors.New("Invalid size %d :: size must be greater than 0", size)
+	}
+
+	if limit := int(maxPerPage.Load()); size > limit {
+		size = limit
+	}
+
+	// Take the model from the items when it is not set
+	if db.model == nil {
+		elemType := typ.Elem().Elem()
+		if elemType.Kind() == reflect.Ptr {
+			elemType = elemType.Elem()
+		}
+
+		db.model = reflect.New(elemType).Interface()
+	}
+
+	var table *Table
+	if table, err = db.modelTable(); err != nil {
+		return
+	}
+
+	var keys []cursorKey
+	if keys, err = db.cursorKeys(table); err != nil {
+		return
+	}
+
+	// Restrict the rows to those after (or before) the cursor
+	backward := false
+	if cursor != "" {
+		var data *cursorData
+		if data, err = decodeCursor(cursor, keys); err != nil {
+			return
+		}
+
+		var values []any
+		if values, err = data.values(); err != nil {
+			return
+		}
+
+		backward = data.Direction == cursorPrev
+
+		db.groupConditionsFrom(0)
+		db.whereStatement.Append(db.cursorCondition(keys, values, backward))
+	}
+
+	var queryBuilder *qb.QueryBuilder
+	if queryBuilder, _, err = db.baseQuery(db.selectColumns()...); err != nil {
+		return
+	}
+
+	// Sort by the keys, in reverse order to read the rows before the cursor
+	for _, key := range keys {
+		if key.desc != backward {
+			queryBuilder.OrderBy(key.column, Desc)
+		} else {
+			queryBuilder.OrderBy(key.column, Asc)
+		}
+	}
+
+	// Read one more row to know whether there is another page
+	queryBuilder.Limit(size+1, 0)
+
+	sqlStr, args := db.selectSql(queryBuilder)
+	if err = db.queryRawContext(ctx, sqlStr, args, items); err != nil {
+		return
+	}
+
+	slice := reflect.ValueOf(items).Elem()
+	hasMore := slice.Len() > size
+	if hasMore {
+		slice.Set(slice.Slice(0, size))
+	}
+
+	// Restore the order of the rows before the cursor
+	if backward {
+		for i, j := 0, slice.Len()-1; i < j; i, j = i+1, j-1 {
+			first, last := slice.Index(i).Interface(), slice.Index(j).Interface()
+			slice.Index(i).Set(reflect.ValueOf(last))
+			slice.Index(j).Set(reflect.ValueOf(first))
+		}
+	}
+
+	pagination.PerPage = size
+
+	if slice.Len() == 0 {
+		return
+	}
+
+	// Going forward, a next page exists when a row was left. Going backward, the page we came from follows.
+	if hasMore || backward {
+		if pagination.Next, err = encodeCursor(cursorNext, keys, slice.Index(slice.Len()-1).Interface()); err != nil {
+			return
+		}
+	}
+
+	// Going backward, a previous page exists when a row was left. Going forward, the page we came from precedes.
+	if (backward && hasMore) || (!backward && cursor != "") {
+		if pagination.Prev, err = encodeCursor(cursorPrev, keys, slice.Index(0).Interface()); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// CursorPaginateOf retrieves a page of rows of type T using keyset pagination.
+//
+// Generic Type:
+//   - T: The type of the model.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the query.
+//   - db (*DBModel): The query to paginate.
+//   - cursor (string): The cursor returned by a previous call. Empty for the first page.
+//   - size (int): The number of items per page.
+//
+// Returns:
+//   - CursorPage[T]: The page of items with its cursors.
+//   - error: ErrInvalidCursor if the cursor is invalid, ErrMissingCursorSecret if no key signs the cursors,
+//     or an error if the query fails.
+func CursorPaginateOf[T any](ctx context.Context, db *DBModel, cursor string, size int) (CursorPage[T], error) {
+	var items []T
+
+	pagination, err := db.CursorPaginate(ctx, cursor, size, &items)
+
+	// For case empty list => return an empty []T
+	if items == nil || err != nil {
+		items = []T{}
+	}
+
+	return CursorPage[T]{
+		Items:            items,
+		CursorPagination: pagination,
+	}, err
+}
+
+// cursorKeys returns the sort columns of keyset pagination: the ORDER BY items followed by
+// the primary key when it is not sorted already.
+//
+// Parameters:
+//   - table (*Table): The table of the model.
+//
+// Returns:
+//   - []cursorKey: The sort columns.
+//   - error: An error if an ORDER BY item is not a column or the table has no primary key.
+func (db *DBModel) cursorKeys(table *Table) ([]cursorKey, error) {
+	var keys []cursorKey
+	hasPrimary := false
+	desc := false
+
+	var primary string
+	if len(table.Primaries) > 0 {
+		primary = table.Primaries[0].Name
+	}
+
+	for _, orderItem := range db.orderByStatement.Items {
+		column := orderItem.Field
+		if column == "" || strings.ContainsAny(column, "\x00( ") {
+			return nil, errors.New("Cursor pagination requires columns in ORDER BY")
+		}
+
+		name := column[strings.LastIndex(column, ".")+1:]
+		desc = orderItem.Direction == Desc
+
+		keys = append(keys, cursorKey{
+			column: column,
+			name:   name,
+			desc:   desc,
+		})
+
+		if name == primary {
+			hasPrimary = true
+		}
+	}
+
+	// The primary key makes the order unique
+	if !hasPrimary {
+		if primary == "" {
+			return nil, errors.New("Cursor pagination requires a primary key")
+		}
+
+		keys = append(keys, cursorKey{
+			column: table.Name + "." + primary,
+			name:   primary,
+			desc:   desc,
+		})
+	}
+
+	return keys, nil
+}
+
+// cursorCondition builds the WHERE condition selecting the rows after (or before) the cursor values.
+// A tuple comparison is used when all keys have the same direction, e.g. (created_at, id) < (?, ?).
+// Otherwise, the comparison is expanded, e.g. (a > ? OR (a = ? AND b < ?)).
+//
+// Parameters:
+//   - keys ([]cursorKey): The sort columns.
+//   - values ([]any): The values of the sort columns in the cursor.
+//   - backward (bool): Whether the rows before the cursor are selected.
+//
+// Returns:
+//   - qb.Condition: The WHERE condition.
+func (db *DBModel) cursorCondition(keys []cursorKey, values []any, backward bool) qb.Condition {
+	// after returns whether the rows after the value of a key are selected
+	after := func(key cursorKey) bool {
+		return key.desc == backward
+	}
+
+	sameDirection := true
+	for _, key := range keys[1:] {
+		if key.desc != keys[0].desc {
+			sameDirection = false
+		}
+	}
+
+	if sameDirection {
+		columns := make([]string, len(keys))
+		placeholders := make([]string, len(keys))
+		for i, key := range keys {
+			columns[i] = key.column
+			placeholders[i] = "?"
+		}
+
+		opt := Lesser
+		if after(keys[0]) {
+			opt = Greater
+		}
+
+		return db.expressionCondition(qb.Condition{
+			Field: Expr(fmt.Sprintf("(%s)", strings.Join(columns, ", "))),
+			Opt:   opt,
+			Value: Expr(fmt.Sprintf("(%s)", strings.Join(placeholders, ", ")), values...),
+			AndOr: And,
+		})
+	}
+
+	var terms []qb.Condition
+	for i, key := range keys {
+		var group []qb.Condition
+
+		for j := 0; j < i; j++ {
+			group = append(group, qb.Condition{
+				Field: keys[j].column,
+				Opt:   Eq,
+				Value: values[j],
+				AndOr: And,
+			})
+		}
+
+		opt := Lesser
+		if after(key) {
+			opt = Greater
+		}
+
+		group = append(group, qb.Condition{
+			Field: key.column,
+			Opt:   opt,
+			Value: values[i],
+			AndOr: And,
+		})
+
+		term := qb.Condition{
+			Group: group,
+			AndOr: Or,
+		}
+		if i == 0 {
+			term.AndOr = And
+		}
+
+		terms = append(terms, term)
+	}
+
+	return qb.Condition{
+		Group: terms,
+		AndOr: And,
+	}
+}
+
+// encodeCursor creates an opaque and signed cursor from the sort values of a row.
+//
+// Parameters:
+//   - direction (cursorDirection): The direction of the cursor.
+//   - keys ([]cursorKey): The sort columns.
+//   - item (any): The row (struct or pointer to struct).
+//
+// Returns:
+//   - string: The cursor.
+//   - error: An error if a sort value can't be encoded.
+func encodeCursor(direction cursorDirection, keys []cursorKey, item any) (string, error) {
+	table, err := ModelData(item)
+	if err != nil {
+		return "", err
+	}
+
+	data := cursorData{
+		Direction: direction,
+	}
+
+	for _, key := range keys {
+		value, ok := table.Values[key.name]
+		if !ok {
+			return "", errors.New("Cursor pagination :: column %s is not a field of the model", key.name)
+		}
+
+		encoded, err := encodeCursorValue(value)
+		if err != nil {
+			return "", err
+		}
+
+		data.Columns = append(data.Columns, key.column)
+		data.Values = append(data.Values, encoded)
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	signature, err := signCursor(payload)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// decodeCursor verifies the signature of a cursor and decodes it.
+//
+// Parameters:
+//   - cursor (string): The cursor.
+//   - keys ([]cursorKey): The sort columns of the current query.
+//
+// Returns:
+//   - *cursorData: The content of the cursor.
+//   - error: ErrInvalidCursor if the cursor is malformed, tampered with or created for another ordering,
+//     ErrMissingCursorSecret if no key is set.
+func decodeCursor(cursor string, keys []cursorKey) (*cursorData, error) {
+	encodedPayload, encodedSignature, found := strings.Cut(cursor, ".")
+	if !found {
+		return nil, ErrInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	expectedSignature, err := signCursor(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hmac.Equal(signature, expectedSignature) {
+		return nil, ErrInvalidCursor
+	}
+
+	var data cursorData
+	if err = json.Unmarshal(payload, &data); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	if data.Direction != cursorNext && data.Direction != cursorPrev {
+		return nil, ErrInvalidCursor
+	}
+
+	// The cursor must belong to the same ordering
+	if len(data.Columns) != len(keys) || len(data.Values) != len(keys) {
+		return nil, ErrInvalidCursor
+	}
+
+	for i, key := range keys {
+		if data.Columns[i] != key.column {
+			return nil, ErrInvalidCursor
+		}
+	}
+
+	return &data, nil
+}
+
+// signCursor computes the HMAC-SHA256 signature of a cursor payload.
+//
+// Parameters:
+//   - payload ([]byte): The payload of the cursor.
+//
+// Returns:
+//   - []byte: The signature.
+//   - error: ErrMissingCursorSecret if no key is set.
+func signCursor(payload []byte) ([]byte, error) {
+	secret, err := cursorSecretKey()
+	if err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+
+	return mac.Sum(nil), nil
+}
+
+// encodeCursorValue encodes a sort value with its kind, so that it is decoded to the same type.
+//
+// Parameters:
+//   - value (any): The sort value.
+//
+// Returns:
+//   - [2]string: The kind and the text of the value.
+//   - error: An error if the type of the value is not supported.
+func encodeCursorValue(value any) ([2]string, error) {
+	// Nullable types (e.g. sql.NullTime) give their underlying value
+	if valuer, ok := value.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		if err != nil {
+			return [2]string{}, err
+		}
+		value = v
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return [2]string{}, errors.New("Cursor pagination :: NULL values can't be used as sort values")
+	case time.Time:
+		return [2]string{"t", v.Format(time.RFC3339Nano)}, nil
+	case string:
+		return [2]string{"s", v}, nil
+	case []byte:
+		return [2]string{"s", string(v)}, nil
+	case bool:
+		return [2]string{"b", strconv.FormatBool(v)}, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.CanInt():
+		return [2]string{"i", strconv.FormatInt(rv.Int(), 10)}, nil
+	case rv.CanUint():
+		return [2]string{"u", strconv.FormatUint(rv.Uint(), 10)}, nil
+	case rv.CanFloat():
+		return [2]string{"f", strconv.FormatFloat(rv.Float(), 'g', -1, 64)}, nil
+	case rv.Kind() == reflect.String:
+		return [2]string{"s", rv.String()}, nil
+	}
+
+	return [2]string{}, errors.New("Cursor pagination :: unsupported sort value type %T", value)
+}
+
+// values decodes the sort values of the cursor.
+//
+// Returns:
+//   - []any: The sort values.
+//   - error: ErrInvalidCursor if a value can't be decoded.
+func (c *cursorData) values() ([]any, error) {
+	values := make([]any, len(c.Values))
+
+	for i, encoded := range c.Values {
+		var value any
+		var err error
+
+		switch encoded[0] {
+		case "t":
+			value, err = time.Parse(time.RFC3339Nano, encoded[1])
+		case "s":
+			value = encoded[1]
+		case "b":
+			value, err = strconv.ParseBool(encoded[1])
+		case "i":
+			value, err = strconv.ParseInt(encoded[1], 10, 64)
+		case "u":
+			value, err = strconv.ParseUint(encoded[1], 10, 64)
+		case "f":
+			value, err = strconv.ParseFloat(encoded[1], 64)
+		default:
+			err = ErrInvalidCursor
+		}
+
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+
+		values[i] = value
+	}
+
+	return values, nil
+}
@@ -0,0 +1,304 @@
+package db
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	qb "github.com/jivegroup/fluentsql"
+)
+
+type cursorPost struct {
+	MetaData  MetaData  `db:"-" model:"table:posts"`
+	ID        int       `db:"id" model:"name:id; type:serial,primary"`
+	Title     string    `db:"title" model:"name:title"`
+	Score     float64   `db:"score" model:"name:score"`
+	Published bool      `db:"published" model:"name:published"`
+	CreatedAt time.Time `db:"created_at" model:"name:created_at"`
+}
+
+var cursorPostKeys = []cursorKey{
+	{column: "created_at", name: "created_at", desc: true},
+	{column: "title", name: "title"},
+	{column: "score", name: "score"},
+	{column: "published", name: "published"},
+	{column: "posts.id", name: "id"},
+}
+
+// useCursorSecret sets the key signing the cursors. The key is restored when the test ends.
+func useCursorSecret(t *testing.T, secret string) {
+	t.Helper()
+
+	cursorSecretMutex.RLock()
+	previousSecret := cursorSecret
+	cursorSecretMutex.RUnlock()
+
+	t.Cleanup(func() {
+		SetCursorSecret(previousSecret)
+	})
+
+	SetCursorSecret([]byte(secret))
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	useCursorSecret(t, "secret")
+
+	post := cursorPost{
+		ID:        42,
+		Title:     "Hello",
+		Score:     4.5,
+		Published: true,
+		CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC),
+	}
+
+	for _, direction := range []cursorDirection{cursorNext, cursorPrev} {
+		cursor, err := encodeCursor(direction, cursorPostKeys, post)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := decodeCursor(cursor, cursorPostKeys)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if data.Direction != direction {
+			t.Errorf("Direction = %q, expected %q", data.Direction, direction)
+		}
+
+		values, err := data.values()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := []any{post.CreatedAt, post.Title, post.Score, post.Published, int64(post.ID)}
+		if !reflect.DeepEqual(values, expected) {
+			t.Errorf("values() = %v, expected %v", values, expected)
+		}
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	useCursorSecret(t, "secret")
+
+	cursor, err := encodeCursor(cursorNext, cursorPostKeys, cursorPost{ID: 1, Title: "Hello"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload, signature, _ := strings.Cut(cursor, ".")
+	rawPayload, _ := base64.RawURLEncoding.DecodeString(payload)
+
+	// Same payload and ordering, signed with another key
+	SetCursorSecret([]byte("other secret"))
+	foreignCursor, _ := encodeCursor(cursorNext, cursorPostKeys, cursorPost{ID: 1, Title: "Hello"})
+	SetCursorSecret([]byte("secret"))
+
+	// Valid signature, but another ordering
+	otherOrdering, _ := encodeCursor(cursorNext, cursorPostKeys[:2], cursorPost{ID: 1, Title: "Hello"})
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "empty", cursor: ""},
+		{name: "without signature", cursor: payload},
+		{name: "malformed payload", cursor: "!" + payload + "." + signature},
+		{name: "malformed signature", cursor: payload + ".!"},
+		{
+			name:   "tampered payload",
+			cursor: base64.RawURLEncoding.EncodeToString([]byte(strings.Replace(string(rawPayload), "Hello", "Hellp", 1))) + "." + signature,
+		},
+		{name: "tampered signature", cursor: payload + "." + base64.RawURLEncoding.EncodeToString([]byte("signature"))},
+		{name: "signed with another key", cursor: foreignCursor},
+		{name: "another ordering", cursor: otherOrdering},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeCursor(tt.cursor, cursorPostKeys); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("decodeCursor() error = %v, expected ErrInvalidCursor", err)
+			}
+		})
+	}
+}
+
+func TestCursorCondition(t *testing.T) {
+	sameDirection := []cursorKey{
+		{column: "created_at", name: "created_at", desc: true},
+		{column: "posts.id", name: "id", desc: true},
+	}
+	mixedDirections := []cursorKey{
+		{column: "created_at", name: "created_at", desc: true},
+		{column: "title", name: "title"},
+		{column: "posts.id", name: "id"},
+	}
+
+	tests := []struct {
+		name     string
+		keys     []cursorKey
+		backward bool
+		expected string
+	}{
+		{
+			name:     "tuple forward",
+			keys:     sameDirection,
+			expected: "SELECT * FROM posts WHERE (created_at, posts.id) < ($1, $2)",
+		},
+		{
+			name:     "tuple backward",
+			keys:     sameDirection,
+			backward: true,
+			expected: "SELECT * FROM posts WHERE (created_at, posts.id) > ($1, $2)",
+		},
+		{
+			name: "expanded forward",
+			keys: mixedDirections,
+			expected: "SELECT * FROM posts WHERE ((created_at < $1) OR (created_at = $2 AND title > $3) " +
+				"OR (created_at = $4 AND title = $5 AND posts.id > $6))",
+		},
+		{
+			name:     "expanded backward",
+			keys:     mixedDirections,
+			backward: true,
+			expected: "SELECT * FROM posts WHERE ((created_at > $1) OR (created_at = $2 AND title < $3) " +
+				"OR (created_at = $4 AND title = $5 AND posts.id < $6))",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useDialect(t, new(qb.PostgreSQLDialect))
+
+			values := []any{"2026-01-02", "Hello", 7}[3-len(tt.keys):]
+
+			db := Instance()
+			sqlStr, args, _ := qb.QueryInstance().
+				Select("*").
+				From("posts").
+				WhereCondition(db.cursorCondition(tt.keys, values, tt.backward)).
+				Sql()
+
+			sqlStr, _, err := db.bindExpressions(sqlStr, args)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if sqlStr != tt.expected {
+				t.Errorf("cursorCondition() = %q, expected %q", sqlStr, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCursorPaginateBackward(t *testing.T) {
+	useCursorSecret(t, "secret")
+
+	keys := []cursorKey{{column: "posts.id", name: "id"}}
+	cursor, err := encodeCursor(cursorPrev, keys, cursorPost{ID: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The rows before the cursor are read in reverse order
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect), fakeResult{
+		columns: []string{"id"},
+		rows:    [][]driver.Value{{int64(4)}, {int64(3)}, {int64(2)}},
+	})
+
+	var posts []cursorPost
+	pagination, err := Instance().CursorPaginate(context.Background(), cursor, 2, &posts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedSql := "SELECT * FROM posts WHERE (posts.id) < ($1) ORDER BY posts.id DESC LIMIT $2 OFFSET $3"
+	if len(fake.statements) != 1 || fake.statements[0] != expectedSql {
+		t.Errorf("statements = %q, expected %q", fake.statements, expectedSql)
+	}
+
+	if len(posts) != 2 || posts[0].ID != 3 || posts[1].ID != 4 {
+		t.Fatalf("posts = %+v, expected the IDs 3 and 4", posts)
+	}
+
+	// The next page starts after the last row, the previous page ends before the first row
+	for _, tt := range []struct {
+		cursor    string
+		direction cursorDirection
+		id        int64
+	}{
+		{cursor: pagination.Next, direction: cursorNext, id: 4},
+		{cursor: pagination.Prev, direction: cursorPrev, id: 3},
+	} {
+		data, err := decodeCursor(tt.cursor, keys)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		values, _ := data.values()
+		if data.Direction != tt.direction || !reflect.DeepEqual(values, []any{tt.id}) {
+			t.Errorf("cursor = %+v, expected %q at %d", data, tt.direction, tt.id)
+		}
+	}
+}
+
+func TestCursorSecretFromEnvironment(t *testing.T) {
+	useCursorSecret(t, "")
+
+	// The key is read when the first cursor is signed, after the environment is loaded
+	t.Setenv("DB_CURSOR_SECRET", "from environment")
+
+	cursor, err := encodeCursor(cursorNext, cursorPostKeys, cursorPost{ID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	SetCursorSecret([]byte("from environment"))
+	if _, err = decodeCursor(cursor, cursorPostKeys); err != nil {
+		t.Errorf("decodeCursor() error = %v, expected the key of the environment", err)
+	}
+}
+
+func TestCursorSecretMissing(t *testing.T) {
+	useCursorSecret(t, "")
+	t.Setenv("DB_CURSOR_SECRET", "")
+
+	if _, err := encodeCursor(cursorNext, cursorPostKeys, cursorPost{ID: 1}); !errors.Is(err, ErrMissingCursorSecret) {
+		t.Errorf("encodeCursor() error = %v, expected ErrMissingCursorSecret", err)
+	}
+
+	if _, err := decodeCursor("e30.c2lnbmF0dXJl", cursorPostKeys); !errors.Is(err, ErrMissingCursorSecret) {
+		t.Errorf("decodeCursor() error = %v, expected ErrMissingCursorSecret", err)
+	}
+}
+
+func TestCursorPaginateTable(t *testing.T) {
+	useCursorSecret(t, "secret")
+
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect), fakeResult{
+		columns: []string{"id"},
+		rows:    [][]driver.Value{{int64(1)}, {int64(2)}},
+	})
+
+	// Table replaces the table qualifying the primary key
+	var posts []cursorPost
+	pagination, err := Instance().Table("archived_posts").CursorPaginate(context.Background(), "", 1, &posts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedSql := "SELECT * FROM archived_posts ORDER BY archived_posts.id ASC LIMIT $1 OFFSET $2"
+	if len(fake.statements) != 1 || fake.statements[0] != expectedSql {
+		t.Errorf("statements = %q, expected %q", fake.statements, expectedSql)
+	}
+
+	keys := []cursorKey{{column: "archived_posts.id", name: "id"}}
+	if _, err = decodeCursor(pagination.Next, keys); err != nil {
+		t.Errorf("decodeCursor() error = %v, expected a cursor of archived_posts", err)
+	}
+}
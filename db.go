@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"github.com/gflydev/core/utils"
 	"github.com/jmoiron/sqlx"
 	"time"
@@ -383,6 +384,33 @@ func dbInstanceTx() *sqlx.Tx {
 	return dbInstance.MustBegin()
 }
 
+// dbInstanceConnTx creates a new database transaction on a dedicated connection of the global database
+// instance, so that the driver connection running the transaction is reachable (see DBModel.RawConn).
+//
+// Parameters:
+//   - ctx (context.Context): The context reserving the connection and starting the transaction.
+//
+// Returns:
+//   - *sqlx.Conn: The connection of the transaction. It must be closed after the transaction ends
+//     to return it to the pool.
+//   - *sqlx.Tx: The new database transaction.
+//   - error: An error if no connection can be reserved or the transaction cannot be started.
+func dbInstanceConnTx(ctx context.Context) (*sqlx.Conn, *sqlx.Tx, error) {
+	conn, err := dbInstance.Connx(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		_ = conn.Close()
+
+		return nil, nil, err
+	}
+
+	return conn, tx, nil
+}
+
 // Load initializes the global database connection using the registered driver.
 // This function establishes the primary database connection that will be used throughout
 // the application lifecycle. It delegates the actual connection establishment to the
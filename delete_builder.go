@@ -3,6 +3,7 @@ package db
 import (
 	"github.com/gflydev/core/errors"
 	qb "github.com/jivegroup/fluentsql"
+	"reflect"
 )
 
 // Delete removes records from the database table based on the provided model and conditions.
@@ -90,33 +91,59 @@ import (
 //   - For mass deletion, use Raw() method with appropriate WHERE clauses
 //   - Composite primary keys are fully supported with AND logic
 //   - The operation is atomic and will either succeed completely or fail without changes
+//   - Models having a `soft_delete` column are soft deleted: the column is set to the
+//     current time instead of removing the rows. Use ForceDelete to remove them
 func (db *DBModel) Delete(model any) error {
 	var err error // Stores errors encountered during the function execution.
 
+	// Reset fluent model builder, also when an error stops the deletion.
+	defer db.reset()
+
 	// Delete using raw SQL if it's set.
 	if db.raw.sqlStr != "" {
 		err = db.execRaw(db.raw.sqlStr, db.raw.args)
-		if err != nil {
-			return err
+		if err == nil {
+			err = db.strictRowsAffected()
 		}
 
-		// Reset fluent model builder.
-		db.reset()
+		return err
 	}
 
-	var table *Table         // Represents the table corresponding to the model.
-	var hasCondition = false // Indicates if any WHERE condition is present.
+	var table *Table              // Represents the table corresponding to the model.
+	var hasCondition = false      // Indicates if any WHERE condition is present.
+	var conditions []qb.Condition // WHERE conditions of the deletion.
+
+	// Deleted rows are returned into a slice: the WHERE conditions select the rows of the element type.
+	typ := reflect.TypeOf(model)
+	isSlice := typ != nil && typ.Kind() == reflect.Ptr && typ.Elem().Kind() == reflect.Slice
+	if isSlice && len(db.returning.columns) == 0 {
+		return errors.New("Invalid data :: Delete of a *Slice requires Returning")
+	}
 
 	// Create a table object from the given model.
-	if table, err = ModelData(model); err != nil {
+	switch {
+	case model == nil || (isSlice && isMapSlice(model)):
+		// Schemaless deletion from Table
+		if db.table == "" {
+			return errors.New("Invalid data :: model is required without Table")
+		}
+		table = db.schemalessTable()
+	case isSlice:
+		table, err = ModelData(reflect.New(scopeModelType(typ)).Interface())
+	default:
+		table, err = ModelData(model)
+	}
+	if err != nil {
 		return err
 	}
-
-	// Create an instance of a delete query builder.
-	deleteBuilder := qb.DeleteInstance().Delete(table.Name)
+	db.applyTable(table)
 
 	// Build WHERE clause using primary columns of the table.
 	for _, primaryColumn := range table.Primaries {
+		if isSlice {
+			break
+		}
+
 		primaryKey := primaryColumn.Name       // The name of the primary column.
 		primaryVal := table.Values[primaryKey] // The value of the primary column.
 
@@ -128,31 +155,26 @@ func (db *DBModel) Delete(model any) error {
 				AndOr: And,        // Logical operator for chaining conditions.
 			}
 
-			// Add the primary key condition to the query builder.
-			deleteBuilder.WhereCondition(wherePrimaryCondition)
+			// Add the primary key condition to the WHERE clause.
+			conditions = append(conditions, wherePrimaryCondition)
 			hasCondition = true // Mark that at least one condition is present.
 		}
 	}
 
+	// Conditions of global scopes alone must not allow deleting all rows.
+	hasCondition = hasCondition || len(db.whereStatement.Conditions) > 0
+
+	// Apply global scopes of the model.
+	db.applyGlobalScopes(model)
+
 	// Build WHERE clause using additional conditions from the condition list.
-	for _, condition := range db.whereStatement.Conditions {
-		switch {
-		case len(condition.Group) > 0:
-			// Append grouped conditions to the query builder.
-			deleteBuilder.WhereGroup(func(whereBuilder qb.WhereBuilder) *qb.WhereBuilder {
-				whereBuilder.WhereCondition(condition.Group...)
-				return &whereBuilder
-			})
-			hasCondition = true
-		case condition.AndOr == And:
-			// Add the AND condition to the query builder.
-			deleteBuilder.Where(condition.Field, condition.Opt, condition.Value)
-			hasCondition = true
-		case condition.AndOr == Or:
-			// Add the OR condition to the query builder.
-			deleteBuilder.WhereOr(condition.Field, condition.Opt, condition.Value)
-			hasCondition = true
-		}
+	// Grouped, AND and OR conditions are kept as they are.
+	conditions = append(conditions, db.whereStatement.Conditions...)
+
+	// Check the version read from the database (optimistic locking).
+	versionCondition, checkVersion := table.versionCondition()
+	if checkVersion {
+		conditions = append(groupConditions(conditions), versionCondition)
 	}
 
 	// Ensure there is at least one WHERE condition.
@@ -160,11 +182,34 @@ func (db *DBModel) Delete(model any) error {
 		return errors.New("Missing WHERE condition for deleting operator")
 	}
 
-	// Execute the delete operation using the constructed delete builder.
-	err = db.delete(deleteBuilder)
+	// Scan the deleted rows back into the model (Returning).
+	if isSlice || isStructPointer(model) {
+		db.returning.dest = model
+	}
+
+	if table.SoftDelete != nil && !db.softDelete.force {
+		// Soft delete: set the deletion time of the rows instead of removing them.
+		err = db.softDeleteRows(model, table, conditions)
+	} else {
+		// Create an instance of a delete query builder.
+		sqlStr, args, _ := qb.DeleteInstance().
+			Delete(table.Name).
+			WhereCondition(conditions...).
+			Sql()
 
-	// Reset fluent model builder.
-	db.reset()
+		// Execute the delete operation using the constructed delete builder.
+		err = db.execReturning(sqlStr, args, table, conditions, true)
+	}
+
+	// No row matches the version: the model was modified or deleted by someone else.
+	if err == nil && checkVersion && db.rowsAffected == 0 {
+		err = table.staleObjectError()
+	}
+
+	// No row matches the conditions in strict mode.
+	if err == nil {
+		err = db.strictRowsAffected()
+	}
 
 	return err
 }
@@ -0,0 +1,325 @@
+package db
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// ====================================================================
+//                           Dirty tracking
+// ====================================================================
+
+// DirtyTracker enables dirty tracking when it is embedded in a model. The column values of the model are
+// kept when it is loaded by First, Last, Get or Find, created or updated. Update then writes only the
+// columns that changed since, so concurrent changes of other columns are not overwritten.
+// When nothing changed, Update doesn't execute any statement and returns nil with RowsAffected 0,
+// in strict mode too (see DBModel.Strict): the existence of the row is not checked.
+//
+// Example:
+//
+//	type User struct {
+//	    MetaData mb.MetaData `db:"-" model:"table:users"`
+//	    mb.DirtyTracker
+//	    ID       int    `db:"id" model:"name:id; type:serial,primary"`
+//	    Name     string `db:"name" model:"name:name"`
+//	    Email    string `db:"email" model:"name:email"`
+//	}
+//
+//	var user User
+//	err := mb.Instance().Where("id", mb.Eq, 1).First(&user)
+//	user.Name = "John"
+//	log.Println(user.IsDirty("name")) // true
+//
+//	// UPDATE users SET name = $1 WHERE id = $2
+//	err = mb.Instance().Update(&user)
+type DirtyTracker struct {
+	original  map[string]any // Column values of the model when it was last read or written
+	modelType reflect.Type   // Struct type of the model embedding the tracker
+	offset    uintptr        // Offset of the tracker in the model
+}
+
+// dirtyTrackerType is the type of the DirtyTracker field.
+var dirtyTrackerType = reflect.TypeOf(DirtyTracker{})
+
+// IsDirty checks whether a column of the model changed since it was loaded.
+// Every column of a model which was not loaded is dirty. It must be called on the model
+// (e.g. user.IsDirty("name")), not on a DirtyTracker copied out of it.
+//
+// Parameters:
+//   - field (string): The column name or the struct field name.
+//
+// Returns:
+//   - bool: true if the column changed; false otherwise or if the column doesn't exist.
+func (d *DirtyTracker) IsDirty(field string) bool {
+	table := d.modelData()
+	if table == nil {
+		return true
+	}
+
+	for _, column := range table.Columns {
+		if column.Name == field || column.Key == field {
+			return table.isChanged(column, d.original)
+		}
+	}
+
+	return false
+}
+
+// Changes returns the columns of the model that changed since it was loaded, with their current values.
+// It returns nil for a model which was not loaded, whose columns are all written by Update. It must be
+// called on the model (e.g. user.Changes()), not on a DirtyTracker copied out of it.
+//
+// Returns:
+//   - map[string]any: The changed column names and their values.
+func (d *DirtyTracker) Changes() map[string]any {
+	table := d.modelData()
+	if table == nil {
+		return nil
+	}
+
+	changes := make(map[string]any)
+
+	for _, column := range table.Columns {
+		if column.Relation != "" || column.Ref != "" {
+			continue
+		}
+
+		if table.isChanged(column, d.original) {
+			changes[column.Name] = table.Values[column.Name]
+		}
+	}
+
+	return changes
+}
+
+// modelData gets the current column values of the model embedding the tracker. The model is found from
+// the address of the tracker, so that a copy of the model reports its own values.
+//
+// Returns:
+//   - *Table: The table of the model, or nil when the model was not loaded.
+func (d *DirtyTracker) modelData() *Table {
+	if d.modelType == nil {
+		return nil
+	}
+
+	value := reflect.NewAt(d.modelType, unsafe.Add(unsafe.Pointer(d), -int(d.offset))).Elem()
+
+	return processModel(d.modelType, value, NewTable())
+}
+
+// isChanged checks whether the value of a column differs from the original values.
+//
+// Parameters:
+//   - column (Column): The column.
+//   - original (map[string]any): The original values, or nil when the model was not loaded.
+//
+// Returns:
+//   - bool: true if the column changed; false otherwise.
+func (tbl *Table) isChanged(column Column, original map[string]any) bool {
+	if original == nil {
+		return true
+	}
+
+	value, ok := original[column.Name]
+	if !ok {
+		return true
+	}
+
+	return !reflect.DeepEqual(snapshotValue(tbl.Values[column.Name]), value)
+}
+
+// hasChanges checks whether a column written by Update differs from the original values.
+// Primary keys, versions and automatic timestamps are not considered.
+//
+// Parameters:
+//   - original (map[string]any): The original values, or nil when the model was not loaded.
+//
+// Returns:
+//   - bool: true if a column changed; false otherwise.
+func (tbl *Table) hasChanges(original map[string]any) bool {
+	for _, column := range tbl.Columns {
+		if column.isNotData() || column.Primary || column.Version || column.AutoCreateTime || column.AutoUpdateTime {
+			continue
+		}
+
+		if tbl.isChanged(column, original) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// originalOf gets the original values kept by the DirtyTracker of a model.
+//
+// Parameters:
+//   - model (any): The model, a Struct or *Struct.
+//
+// Returns:
+//   - map[string]any: The original values, or nil when the model was not loaded or doesn't embed DirtyTracker.
+func originalOf(model any) map[string]any {
+	value := reflect.Indirect(reflect.ValueOf(model))
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	index, ok := dirtyTrackerIndex(value.Type())
+	if !ok {
+		return nil
+	}
+
+	return value.FieldByIndex(index).Interface().(DirtyTracker).original
+}
+
+// snapshotModels keeps the column values of the loaded or written models which embed DirtyTracker.
+//
+// Parameters:
+//   - model (any): A *Struct, a slice of Struct or *Struct, or a pointer to such a slice.
+//     Other values are ignored.
+func snapshotModels(model any) {
+	value := reflect.ValueOf(model)
+	if value.Kind() == reflect.Ptr && !value.IsNil() && value.Elem().Kind() == reflect.Slice {
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Ptr:
+		if !value.IsNil() && value.Elem().Kind() == reflect.Struct {
+			snapshotStruct(value.Elem())
+		}
+	case reflect.Slice:
+		for i := 0; i < value.Len(); i++ {
+			item := value.Index(i)
+			if item.Kind() == reflect.Ptr {
+				if item.IsNil() {
+					continue
+				}
+				item = item.Elem()
+			}
+
+			if item.Kind() != reflect.Struct || !item.CanSet() {
+				return
+			}
+
+			snapshotStruct(item)
+		}
+	}
+}
+
+// snapshotStruct keeps the column values of a model in its DirtyTracker.
+//
+// Parameters:
+//   - value (reflect.Value): The addressable struct value of the model.
+func snapshotStruct(value reflect.Value) {
+	index, ok := dirtyTrackerIndex(value.Type())
+	if !ok {
+		return
+	}
+
+	offset, ok := dirtyTrackerOffset(value.Type(), index)
+	if !ok {
+		return
+	}
+
+	table := processModel(value.Type(), value, NewTable())
+	original := make(map[string]any, len(table.Columns))
+
+	for _, column := range table.Columns {
+		if column.Relation != "" || column.Ref != "" {
+			continue
+		}
+
+		original[column.Name] = snapshotValue(table.Values[column.Name])
+	}
+
+	value.FieldByIndex(index).Set(reflect.ValueOf(DirtyTracker{
+		original:  original,
+		modelType: value.Type(),
+		offset:    offset,
+	}))
+}
+
+// dirtyTrackerIndex finds the DirtyTracker field embedded in a model type.
+//
+// Parameters:
+//   - typ (reflect.Type): The struct type of the model.
+//
+// Returns:
+//   - []int: The index sequence of the field.
+//   - bool: true if the model embeds DirtyTracker; false otherwise.
+func dirtyTrackerIndex(typ reflect.Type) ([]int, bool) {
+	field, ok := typ.FieldByName(dirtyTrackerType.Name())
+	if !ok || !field.Anonymous || field.Type != dirtyTrackerType {
+		return nil, false
+	}
+
+	return field.Index, true
+}
+
+// dirtyTrackerOffset computes the offset of the DirtyTracker field in a model type.
+//
+// Parameters:
+//   - typ (reflect.Type): The struct type of the model.
+//   - index ([]int): The index sequence of the field.
+//
+// Returns:
+//   - uintptr: The offset of the field.
+//   - bool: true if the field is stored in the model; false if it is reached through an embedded pointer.
+func dirtyTrackerOffset(typ reflect.Type, index []int) (uintptr, bool) {
+	var offset uintptr
+
+	for _, i := range index {
+		if typ.Kind() != reflect.Struct {
+			return 0, false
+		}
+
+		field := typ.Field(i)
+		offset += field.Offset
+		typ = field.Type
+	}
+
+	return offset, true
+}
+
+// snapshotValue copies the data referenced by a column value, so that later changes of the
+// model through pointers, slices or maps are detected.
+//
+// Parameters:
+//   - value (any): The column value.
+//
+// Returns:
+//   - any: The value to keep or to compare with the kept one.
+func snapshotValue(value any) any {
+	v := reflect.ValueOf(value)
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+
+		return snapshotValue(v.Elem().Interface())
+	case reflect.Slice:
+		if v.IsNil() {
+			return value
+		}
+
+		clone := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(clone, v)
+
+		return clone.Interface()
+	case reflect.Map:
+		if v.IsNil() {
+			return value
+		}
+
+		clone := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			clone.SetMapIndex(iter.Key(), iter.Value())
+		}
+
+		return clone.Interface()
+	default:
+		return value
+	}
+}
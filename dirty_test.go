@@ -0,0 +1,265 @@
+package db
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	qb "github.com/jivegroup/fluentsql"
+)
+
+// dirtyTags is a JSON column scanned into a map.
+type dirtyTags map[string]string
+
+func (tags dirtyTags) Value() (driver.Value, error) {
+	return json.Marshal(tags)
+}
+
+func (tags *dirtyTags) Scan(src any) error {
+	data, ok := src.([]byte)
+	if !ok {
+		return errors.New("dirtyTags: unsupported source")
+	}
+
+	return json.Unmarshal(data, tags)
+}
+
+type dirtyProfile struct {
+	MetaData MetaData `db:"-" model:"table:profiles"`
+	DirtyTracker
+	ID       int       `db:"id" model:"name:id; type:serial,primary"`
+	Name     string    `db:"name" model:"name:name"`
+	Nickname *string   `db:"nickname" model:"name:nickname"`
+	Avatar   []byte    `db:"avatar" model:"name:avatar"`
+	Tags     dirtyTags `db:"tags" model:"name:tags"`
+}
+
+// profileRows is the result of a query of the profiles.
+func profileRows(ids ...int64) fakeResult {
+	result := fakeResult{columns: []string{"id", "name", "nickname", "avatar", "tags"}}
+	for _, id := range ids {
+		result.rows = append(result.rows, []driver.Value{id, "John", "johnny", []byte{1, 2}, []byte(`{"a":"1"}`)})
+	}
+
+	return result
+}
+
+func TestDirtyTrackerSnapshot(t *testing.T) {
+	tests := []struct {
+		name   string
+		result fakeResult
+		load   func(db *DBModel) (*dirtyProfile, error)
+	}{
+		{
+			name:   "First",
+			result: profileRows(1),
+			load: func(db *DBModel) (*dirtyProfile, error) {
+				var profile dirtyProfile
+				err := db.Where("id", Eq, 1).First(&profile)
+
+				return &profile, err
+			},
+		},
+		{
+			name:   "Find",
+			result: profileRows(1, 2),
+			load: func(db *DBModel) (*dirtyProfile, error) {
+				var profiles []dirtyProfile
+				_, err := db.WithCount(CountNone).Find(&profiles)
+				if len(profiles) != 2 {
+					return nil, errors.New("expected 2 profiles")
+				}
+
+				return &profiles[1], err
+			},
+		},
+		{
+			name:   "Create",
+			result: fakeResult{columns: []string{"id"}, rows: [][]driver.Value{{int64(1)}}},
+			load: func(db *DBModel) (*dirtyProfile, error) {
+				nickname := "johnny"
+				profile := dirtyProfile{Name: "John", Nickname: &nickname, Avatar: []byte{1, 2}, Tags: dirtyTags{"a": "1"}}
+				err := db.Create(&profile)
+
+				return &profile, err
+			},
+		},
+		{
+			name:   "Update",
+			result: fakeResult{affected: 1},
+			load: func(db *DBModel) (*dirtyProfile, error) {
+				nickname := "johnny"
+				profile := dirtyProfile{ID: 1, Name: "John", Nickname: &nickname, Avatar: []byte{1, 2}, Tags: dirtyTags{"a": "1"}}
+				err := db.Update(&profile)
+
+				return &profile, err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useFakeDB(t, new(qb.PostgreSQLDialect), tt.result)
+
+			profile, err := tt.load(Instance())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if profile.IsDirty("name") {
+				t.Error(`IsDirty("name") = true, expected false after loading`)
+			}
+
+			if changes := profile.Changes(); len(changes) != 0 {
+				t.Errorf("Changes() = %v, expected none", changes)
+			}
+
+			profile.Name = "Jane"
+
+			if !profile.IsDirty("name") || !profile.IsDirty("Name") {
+				t.Error(`IsDirty("name") = false, expected true after a change`)
+			}
+
+			if changes := profile.Changes(); !reflect.DeepEqual(changes, map[string]any{"name": "Jane"}) {
+				t.Errorf("Changes() = %v, expected map[name:Jane]", changes)
+			}
+		})
+	}
+}
+
+func TestDirtyTrackerReferences(t *testing.T) {
+	tests := []struct {
+		name   string
+		column string
+		change func(profile *dirtyProfile)
+	}{
+		{
+			name:   "pointer",
+			column: "nickname",
+			change: func(profile *dirtyProfile) {
+				*profile.Nickname = "jo"
+			},
+		},
+		{
+			name:   "slice",
+			column: "avatar",
+			change: func(profile *dirtyProfile) {
+				profile.Avatar[0] = 9
+			},
+		},
+		{
+			name:   "map",
+			column: "tags",
+			change: func(profile *dirtyProfile) {
+				profile.Tags["b"] = "2"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useFakeDB(t, new(qb.PostgreSQLDialect), profileRows(1))
+
+			var profile dirtyProfile
+			if err := Instance().Where("id", Eq, 1).First(&profile); err != nil {
+				t.Fatal(err)
+			}
+
+			// Changes through the references of the fields are detected
+			tt.change(&profile)
+
+			if !profile.IsDirty(tt.column) {
+				t.Errorf("IsDirty(%q) = false, expected true", tt.column)
+			}
+
+			changes := profile.Changes()
+			if _, ok := changes[tt.column]; !ok || len(changes) != 1 {
+				t.Errorf("Changes() = %v, expected only %s", changes, tt.column)
+			}
+		})
+	}
+}
+
+func TestDirtyTrackerNotLoaded(t *testing.T) {
+	profile := dirtyProfile{Name: "John"}
+
+	if !profile.IsDirty("name") {
+		t.Error(`IsDirty("name") = false, expected true for a model not loaded`)
+	}
+
+	if changes := profile.Changes(); changes != nil {
+		t.Errorf("Changes() = %v, expected nil for a model not loaded", changes)
+	}
+}
+
+func TestDirtyTrackerCopy(t *testing.T) {
+	useFakeDB(t, new(qb.PostgreSQLDialect), profileRows(1, 2))
+
+	var profiles []dirtyProfile
+	if _, err := Instance().WithCount(CountNone).Find(&profiles); err != nil {
+		t.Fatal(err)
+	}
+
+	// A copy of the model reports its own changes
+	for _, profile := range profiles {
+		profile.Name = "Jane"
+
+		if !profile.IsDirty("name") {
+			t.Error(`IsDirty("name") of the copy = false, expected true`)
+		}
+	}
+
+	if profiles[0].IsDirty("name") {
+		t.Error(`IsDirty("name") of the loaded model = true, expected false`)
+	}
+}
+
+func TestUpdateDirty(t *testing.T) {
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect), profileRows(1), fakeResult{affected: 1})
+
+	var profile dirtyProfile
+	if err := Instance().Where("id", Eq, 1).First(&profile); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the changed columns are written
+	profile.Name = "Jane"
+
+	db := Instance()
+	if err := db.Update(&profile); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "UPDATE profiles SET name = $1 WHERE id = $2"
+	if len(fake.statements) != 2 || fake.statements[1] != expected {
+		t.Errorf("statements = %q, expected %q", fake.statements, expected)
+	}
+
+	if profile.IsDirty("name") {
+		t.Error(`IsDirty("name") = true, expected false after Update`)
+	}
+
+	// Nothing changed: no statement, also in strict mode
+	if err := db.Strict().Update(&profile); err != nil {
+		t.Errorf("Strict().Update() = %v, expected nil when nothing changed", err)
+	}
+
+	if db.RowsAffected() != 0 {
+		t.Errorf("RowsAffected() = %d, expected 0", db.RowsAffected())
+	}
+
+	if len(fake.statements) != 2 {
+		t.Errorf("statements = %q, expected no statement for an unchanged model", fake.statements[2:])
+	}
+
+	// Columns set explicitly are written
+	if err := db.Strict().Set("name", "John").Update(&profile); !errors.Is(err, ErrNoRowsAffected) {
+		t.Errorf("Strict().Set().Update() = %v, expected ErrNoRowsAffected", err)
+	}
+
+	if len(fake.statements) != 3 {
+		t.Errorf("statements = %q, expected the statement of the set column", fake.statements)
+	}
+}
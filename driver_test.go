@@ -0,0 +1,166 @@
+package db
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"sync"
+	"testing"
+
+	qb "github.com/jivegroup/fluentsql"
+	"github.com/jmoiron/sqlx"
+)
+
+// Test driver recording the executed statements, so that the generated SQL is checked without a database.
+
+// fakeResult is the result of a statement executed by the test driver.
+type fakeResult struct {
+	columns      []string
+	types        []string // The database type names of the columns
+	rows         [][]driver.Value
+	affected     int64
+	lastInsertID int64
+	err          error // The error of the statement
+}
+
+// fakeDatabase records the statements and returns the queued results in order.
+// A statement without queued result affects no row and returns no row.
+type fakeDatabase struct {
+	statements   []string
+	args         [][]driver.Value
+	results      []fakeResult
+	transactions []string // BEGIN, COMMIT and ROLLBACK in order
+}
+
+// next records a statement and returns its result.
+func (f *fakeDatabase) next(query string, args []driver.Value) fakeResult {
+	f.statements = append(f.statements, query)
+	f.args = append(f.args, args)
+
+	if len(f.results) == 0 {
+		return fakeResult{}
+	}
+
+	result := f.results[0]
+	f.results = f.results[1:]
+
+	return result
+}
+
+var (
+	fakeOnce    sync.Once
+	currentFake *fakeDatabase
+)
+
+// useFakeDB connects the package to a new test database for the dialect. The connection and the
+// dialect are restored when the test ends.
+func useFakeDB(t *testing.T, dialect qb.Dialect, results ...fakeResult) *fakeDatabase {
+	t.Helper()
+
+	fakeOnce.Do(func() {
+		sql.Register("fakedb", fakeDriver{})
+	})
+
+	useDialect(t, dialect)
+
+	previousInstance := dbInstance
+	t.Cleanup(func() {
+		_ = dbInstance.Close()
+		dbInstance, currentFake = previousInstance, nil
+	})
+
+	currentFake = &fakeDatabase{results: results}
+	dbInstance = &DB{sqlx.MustOpen("fakedb", "")}
+	dbInstance.SetMaxOpenConns(1)
+
+	return currentFake
+}
+
+// useDialect sets the dialect of the generated SQL. The dialect is restored when the test ends.
+func useDialect(t *testing.T, dialect qb.Dialect) {
+	t.Helper()
+
+	previousDialect := qb.DefaultDialect()
+	t.Cleanup(func() {
+		qb.SetDialect(previousDialect)
+	})
+
+	qb.SetDialect(dialect)
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{query}, nil }
+func (fakeConn) Close() error                              { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                 { return fakeConn{}, trackTransaction("BEGIN") }
+func (fakeConn) Commit() error                             { return trackTransaction("COMMIT") }
+func (fakeConn) Rollback() error                           { return trackTransaction("ROLLBACK") }
+
+// trackTransaction records a transaction operation.
+func trackTransaction(operation string) error {
+	if currentFake != nil {
+		currentFake.transactions = append(currentFake.transactions, operation)
+	}
+
+	return nil
+}
+
+type fakeStmt struct{ query string }
+
+func (s fakeStmt) Close() error  { return nil }
+func (s fakeStmt) NumInput() int { return -1 }
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	result := currentFake.next(s.query, args)
+	if result.err != nil {
+		return nil, result.err
+	}
+
+	return fakeExecResult(result), nil
+}
+
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	result := currentFake.next(s.query, args)
+	if result.err != nil {
+		return nil, result.err
+	}
+
+	return &fakeRows{columns: result.columns, types: result.types, rows: result.rows}, nil
+}
+
+type fakeExecResult fakeResult
+
+func (r fakeExecResult) LastInsertId() (int64, error) { return r.lastInsertID, nil }
+func (r fakeExecResult) RowsAffected() (int64, error) { return r.affected, nil }
+
+type fakeRows struct {
+	columns []string
+	types   []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) ColumnTypeDatabaseTypeName(index int) string {
+	if index < len(r.types) {
+		return r.types[index]
+	}
+
+	return ""
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+
+	return nil
+}
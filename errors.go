@@ -0,0 +1,69 @@
+package db
+
+import (
+	"github.com/gflydev/core/errors"
+	"strings"
+)
+
+// ====================================================================
+//                              Errors
+// ====================================================================
+
+// ErrStaleObject is returned when a model with a `version` column was modified or deleted
+// by someone else since it was read. Use errors.Is(err, ErrStaleObject) to detect it.
+var ErrStaleObject = errors.New("Stale object")
+
+// StaleObjectError represents an update or deletion rejected by optimistic locking.
+//
+// Fields:
+//   - Table (string): The table of the model.
+//   - Version (any): The version held by the model.
+type StaleObjectError struct {
+	Table   string
+	Version any
+}
+
+// Error returns the error message.
+func (e StaleObjectError) Error() string {
+	return errors.ToStr("Stale object :: %s version %v was modified or deleted", e.Table, e.Version)
+}
+
+// Is reports whether the target is ErrStaleObject.
+func (e StaleObjectError) Is(target error) bool {
+	return target == ErrStaleObject
+}
+
+// ErrNoRowsAffected is returned in strict mode (see DBModel.Strict) when an UPDATE or DELETE
+// statement affects no row.
+var ErrNoRowsAffected = errors.New("No rows affected")
+
+// ErrInvalidCursor is returned when a pagination cursor is malformed, was tampered with,
+// or was created for another ordering.
+var ErrInvalidCursor = errors.New("Invalid cursor")
+
+// ErrMissingCursorSecret is returned by CursorPaginate when no key signs the pagination cursors.
+// Set the DB_CURSOR_SECRET environment variable or call SetCursorSecret.
+var ErrMissingCursorSecret = errors.New("Missing cursor secret :: set DB_CURSOR_SECRET or call SetCursorSecret")
+
+// BatchError reports every error of a batch operation. errors.Is and errors.As check each error.
+//
+// Fields:
+//   - Errors ([]error): The errors, in order.
+type BatchError struct {
+	Errors []error
+}
+
+// Error returns the error message.
+func (e BatchError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+
+	return errors.ToStr("Batch errors :: %s", strings.Join(messages, "; "))
+}
+
+// Unwrap returns the errors of the batch.
+func (e BatchError) Unwrap() []error {
+	return e.Errors
+}
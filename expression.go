@@ -0,0 +1,323 @@
+package db
+
+import (
+	"github.com/gflydev/core/errors"
+	qb "github.com/jivegroup/fluentsql"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// ====================================================================
+//                            Expression
+// ====================================================================
+
+// Expression represents a raw SQL fragment with its own bindings.
+// Every `?` in the fragment is a binding placeholder. It is converted to the
+// placeholder of the current dialect and its argument is placed at the right
+// position among the arguments of the whole query.
+//
+// Fields:
+//   - sqlStr (string): The SQL fragment with `?` placeholders.
+//   - args ([]any): The ordered arguments corresponding to the placeholders.
+type Expression struct {
+	sqlStr string // The SQL fragment with `?` placeholders
+	args   []any  // The ordered arguments corresponding to the placeholders
+}
+
+// Expr creates a raw SQL expression with bindings.
+// Expressions are accepted by Select, OrderBy, GroupByExpr, Set, Join, Where and Having.
+//
+// Parameters:
+//   - sqlStr (string): The SQL fragment. Use `?` for each binding regardless of the dialect.
+//     A `?` inside a quoted literal is not a binding. Write `??` for the PostgreSQL jsonb
+//     operator `?`; the operators `?|` and `?&` are written as they are.
+//   - args (...any): The values bound to the placeholders, in order. A value which is an Expression
+//     is written in place of its placeholder.
+//
+// Returns:
+//   - Expression: The expression instance.
+//
+// Examples:
+//
+//	db.Select("id", mb.Expr("COALESCE(nickname, ?) AS nickname", "anon")).Find(&users)
+//	db.OrderBy(mb.Expr("FIELD(status, ?, ?)", "active", "pending"), mb.Asc)
+//	db.Model(&Post{}).Where("id", mb.Eq, 1).Set("views", mb.Expr("views + ?", 1)).Update(&post)
+func Expr(sqlStr string, args ...any) Expression {
+	return Expression{
+		sqlStr: sqlStr,
+		args:   args,
+	}
+}
+
+// String returns the SQL fragment of the expression.
+//
+// Returns:
+//   - string: The SQL fragment with `?` placeholders.
+func (e Expression) String() string {
+	return e.sqlStr
+}
+
+// Args returns the bindings of the expression.
+//
+// Returns:
+//   - []any: The ordered arguments of the expression.
+func (e Expression) Args() []any {
+	return e.args
+}
+
+// ====================================================================
+//                         Expression binding
+// ====================================================================
+
+// expressionMarker wraps the key of a registered expression inside the SQL string.
+// It never reaches the database because bindExpressions replaces it.
+const expressionMarker = "\x00"
+
+// expressionSeq generates unique keys for registered expressions.
+var expressionSeq atomic.Uint64
+
+// expression returns the SQL text standing for an expression in the query.
+// Expressions without bindings are written as-is, the others are registered on the
+// DBModel and represented by a marker until the SQL is executed.
+//
+// Parameters:
+//   - e (Expression): The expression to place in the query.
+//
+// Returns:
+//   - string: The SQL text or the marker of the expression.
+func (db *DBModel) expression(e Expression) string {
+	if len(e.args) == 0 {
+		return e.sqlStr
+	}
+
+	if db.expressions == nil {
+		db.expressions = make(map[string]Expression)
+	}
+
+	key := expressionMarker + strconv.FormatUint(expressionSeq.Add(1), 10) + expressionMarker
+	db.expressions[key] = e
+
+	return key
+}
+
+// expressionField converts a field which may be an Expression to its SQL text.
+//
+// Parameters:
+//   - field (any): The field, column or expression.
+//
+// Returns:
+//   - any: The field unchanged or the SQL text of the expression.
+func (db *DBModel) expressionField(field any) any {
+	if e, ok := field.(Expression); ok {
+		return db.expression(e)
+	}
+
+	return field
+}
+
+// expressionValue converts a value which may be an Expression to a ValueField,
+// so that fluentsql writes it into the query instead of binding it.
+//
+// Parameters:
+//   - value (any): The value or expression.
+//
+// Returns:
+//   - any: The value unchanged or a ValueField holding the SQL text of the expression.
+func (db *DBModel) expressionValue(value any) any {
+	if e, ok := value.(Expression); ok {
+		return ValueField(db.expression(e))
+	}
+
+	return value
+}
+
+// expressionCondition converts the expressions of a condition and its group.
+//
+// Parameters:
+//   - condition (qb.Condition): The condition to convert.
+//
+// Returns:
+//   - qb.Condition: The condition with expressions converted.
+func (db *DBModel) expressionCondition(condition qb.Condition) qb.Condition {
+	condition.Field = db.expressionField(condition.Field)
+	condition.Value = db.expressionValue(condition.Value)
+
+	for i := range condition.Group {
+		condition.Group[i] = db.expressionCondition(condition.Group[i])
+	}
+
+	return condition
+}
+
+// bindExpressions replaces the expression markers of a SQL string with the expressions
+// and rebuilds the arguments in placeholder order. PostgreSQL placeholders are renumbered.
+// Quoted literals and identifiers are written unchanged.
+//
+// Parameters:
+//   - sqlStr (string): The SQL string generated by fluentsql.
+//   - args ([]any): The arguments generated by fluentsql.
+//
+// Returns:
+//   - string: The SQL string with expressions and dialect placeholders.
+//   - []any: The arguments of the SQL string, in placeholder order.
+//   - error: An error if a marker belongs to an unknown expression (e.g. in a subquery).
+func (db *DBModel) bindExpressions(sqlStr string, args []any) (string, []any, error) {
+	if !strings.Contains(sqlStr, expressionMarker) {
+		return sqlStr, args, nil
+	}
+
+	isPostgreSQL := qb.IsDialect(qb.PostgreSQL)
+
+	var sb strings.Builder
+	var boundArgs []any
+	var argIndex int
+
+	for i := 0; i < len(sqlStr); i++ {
+		switch {
+		case isQuote(sqlStr[i]):
+			// Quoted literal or identifier: keep it as it is
+			end := quotedEnd(sqlStr, i)
+			sb.WriteString(sqlStr[i:end])
+
+			i = end - 1
+		case sqlStr[i] == expressionMarker[0]:
+			// Expression marker: write the expression with its own bindings
+			end := strings.Index(sqlStr[i+1:], expressionMarker)
+			if end < 0 {
+				return "", nil, errors.New("Invalid expression in SQL %s", sqlStr)
+			}
+
+			key := sqlStr[i : i+end+2]
+			e, ok := db.expressions[key]
+			if !ok {
+				return "", nil, errors.New("Expression bindings are not supported in subqueries")
+			}
+
+			boundArgs = writeExpression(&sb, e, boundArgs)
+
+			i += end + 1
+		case isPostgreSQL && sqlStr[i] == '$' && i+1 < len(sqlStr) && isDigit(sqlStr[i+1]):
+			// PostgreSQL placeholder: renumber it
+			j := i + 1
+			for j < len(sqlStr) && isDigit(sqlStr[j]) {
+				j++
+			}
+
+			position, _ := strconv.Atoi(sqlStr[i+1 : j])
+			if position < 1 || position > len(args) {
+				sb.WriteString(sqlStr[i:j])
+			} else {
+				boundArgs = append(boundArgs, args[position-1])
+				sb.WriteString(qb.DefaultDialect().Placeholder(len(boundArgs)))
+			}
+
+			i = j - 1
+		case !isPostgreSQL && sqlStr[i] == '?' && argIndex < len(args):
+			// Positional placeholder: keep the order of arguments
+			boundArgs = append(boundArgs, args[argIndex])
+			argIndex++
+			sb.WriteByte('?')
+		default:
+			sb.WriteByte(sqlStr[i])
+		}
+	}
+
+	return sb.String(), boundArgs, nil
+}
+
+// writeExpression writes an expression with the dialect placeholders of its bindings.
+// A binding which is an expression is written in place with its own bindings.
+// Quoted literals and identifiers, the PostgreSQL operators `?|` and `?&` and the escaped
+// question mark `??` are not placeholders.
+//
+// Parameters:
+//   - sb (*strings.Builder): The SQL string being built.
+//   - e (Expression): The expression to write.
+//   - boundArgs ([]any): The arguments of the SQL string written so far.
+//
+// Returns:
+//   - []any: The arguments of the SQL string, including the bindings of the expression.
+func writeExpression(sb *strings.Builder, e Expression, boundArgs []any) []any {
+	var n int
+	for j := 0; j < len(e.sqlStr); j++ {
+		switch {
+		case isQuote(e.sqlStr[j]):
+			// Quoted literal or identifier: keep it as it is
+			end := quotedEnd(e.sqlStr, j)
+			sb.WriteString(e.sqlStr[j:end])
+
+			j = end - 1
+
+			continue
+		case e.sqlStr[j] != '?':
+			sb.WriteByte(e.sqlStr[j])
+
+			continue
+		case j+1 < len(e.sqlStr) && e.sqlStr[j+1] == '?':
+			// Escaped question mark, e.g. the PostgreSQL jsonb operator `??` written as `?`
+			sb.WriteByte('?')
+			j++
+
+			continue
+		case j+1 < len(e.sqlStr) && (e.sqlStr[j+1] == '|' || e.sqlStr[j+1] == '&'):
+			// PostgreSQL jsonb operators `?|` and `?&`
+			sb.WriteString(e.sqlStr[j : j+2])
+			j++
+
+			continue
+		case n >= len(e.args):
+			sb.WriteByte(e.sqlStr[j])
+
+			continue
+		}
+
+		if nested, ok := e.args[n].(Expression); ok {
+			boundArgs = writeExpression(sb, nested, boundArgs)
+		} else {
+			boundArgs = append(boundArgs, e.args[n])
+			sb.WriteString(qb.DefaultDialect().Placeholder(len(boundArgs)))
+		}
+		n++
+	}
+
+	return boundArgs
+}
+
+// isQuote reports whether the byte starts a quoted literal or identifier.
+func isQuote(c byte) bool {
+	return c == '\'' || c == '"' || c == '`'
+}
+
+// quotedEnd returns the position following the quoted literal or identifier starting at i.
+// A doubled quote is part of the literal, as well as a quote escaped by a backslash in MySQL.
+// An unterminated literal ends with the SQL string.
+//
+// Parameters:
+//   - sqlStr (string): The SQL string.
+//   - i (int): The position of the opening quote.
+//
+// Returns:
+//   - int: The position following the closing quote.
+func quotedEnd(sqlStr string, i int) int {
+	quote := sqlStr[i]
+	backslashEscape := qb.IsDialect(qb.MySQL) && quote != '`'
+
+	for j := i + 1; j < len(sqlStr); j++ {
+		switch {
+		case backslashEscape && sqlStr[j] == '\\':
+			j++
+		case sqlStr[j] == quote && j+1 < len(sqlStr) && sqlStr[j+1] == quote:
+			j++
+		case sqlStr[j] == quote:
+			return j + 1
+		}
+	}
+
+	return len(sqlStr)
+}
+
+// isDigit reports whether the byte is an ASCII digit.
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,230 @@
+package db
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+
+	qb "github.com/jivegroup/fluentsql"
+)
+
+func TestBindExpressions(t *testing.T) {
+	tests := []struct {
+		name         string
+		dialect      qb.Dialect
+		build        func(db *DBModel) (string, []any)
+		expected     string
+		expectedArgs []any
+	}{
+		{
+			name:    "PostgreSQL without expression",
+			dialect: new(qb.PostgreSQLDialect),
+			build: func(db *DBModel) (string, []any) {
+				return "SELECT * FROM users WHERE id = $1", []any{1}
+			},
+			expected:     "SELECT * FROM users WHERE id = $1",
+			expectedArgs: []any{1},
+		},
+		{
+			name:    "PostgreSQL renumbering with mixed arguments",
+			dialect: new(qb.PostgreSQLDialect),
+			build: func(db *DBModel) (string, []any) {
+				views := db.expression(Expr("views + ?", 2))
+				rank := db.expression(Expr("GREATEST(rank, ?, ?)", 3, 4))
+
+				return "UPDATE posts SET title = $1, views = " + views + ", rank = " + rank + " WHERE id = $2",
+					[]any{"title", 9}
+			},
+			expected:     "UPDATE posts SET title = $1, views = views + $2, rank = GREATEST(rank, $3, $4) WHERE id = $5",
+			expectedArgs: []any{"title", 2, 3, 4, 9},
+		},
+		{
+			name:    "PostgreSQL placeholders out of order",
+			dialect: new(qb.PostgreSQLDialect),
+			build: func(db *DBModel) (string, []any) {
+				score := db.expression(Expr("score * ?", 10))
+
+				return "SELECT " + score + " FROM users WHERE id = $2 OR parent_id = $1 OR owner_id = $2",
+					[]any{"parent", "id"}
+			},
+			expected:     "SELECT score * $1 FROM users WHERE id = $2 OR parent_id = $3 OR owner_id = $4",
+			expectedArgs: []any{10, "id", "parent", "id"},
+		},
+		{
+			name:    "PostgreSQL placeholder without argument",
+			dialect: new(qb.PostgreSQLDialect),
+			build: func(db *DBModel) (string, []any) {
+				price := db.expression(Expr("price > ?", 5))
+
+				return "SELECT '$9' AS label FROM items WHERE " + price, nil
+			},
+			expected:     "SELECT '$9' AS label FROM items WHERE price > $1",
+			expectedArgs: []any{5},
+		},
+		{
+			name:    "MySQL mixed arguments",
+			dialect: new(qb.MySQLDialect),
+			build: func(db *DBModel) (string, []any) {
+				views := db.expression(Expr("views + ?", 2))
+
+				return "UPDATE posts SET title = ?, views = " + views + " WHERE id = ?", []any{"title", 9}
+			},
+			expected:     "UPDATE posts SET title = ?, views = views + ? WHERE id = ?",
+			expectedArgs: []any{"title", 2, 9},
+		},
+		{
+			name:    "nested expressions",
+			dialect: new(qb.PostgreSQLDialect),
+			build: func(db *DBModel) (string, []any) {
+				name := db.expression(Expr("COALESCE(?, ?)", Expr("NULLIF(nickname, ?)", ""), "anon"))
+
+				return "SELECT " + name + " FROM users WHERE id = $1", []any{1}
+			},
+			expected:     "SELECT COALESCE(NULLIF(nickname, $1), $2) FROM users WHERE id = $3",
+			expectedArgs: []any{"", "anon", 1},
+		},
+		{
+			name:    "nested expression without bindings",
+			dialect: new(qb.MySQLDialect),
+			build: func(db *DBModel) (string, []any) {
+				updated := db.expression(Expr("COALESCE(?, ?)", Expr("updated_at"), "2006-01-02"))
+
+				return "SELECT " + updated + " FROM users WHERE id = ?", []any{1}
+			},
+			expected:     "SELECT COALESCE(updated_at, ?) FROM users WHERE id = ?",
+			expectedArgs: []any{"2006-01-02", 1},
+		},
+		{
+			name:    "arguments containing the marker",
+			dialect: new(qb.PostgreSQLDialect),
+			build: func(db *DBModel) (string, []any) {
+				name := db.expression(Expr("CONCAT(name, ?)", expressionMarker+"1"+expressionMarker))
+
+				return "SELECT " + name + " FROM users WHERE code = $1", []any{"a" + expressionMarker + "b"}
+			},
+			expected:     "SELECT CONCAT(name, $1) FROM users WHERE code = $2",
+			expectedArgs: []any{expressionMarker + "1" + expressionMarker, "a" + expressionMarker + "b"},
+		},
+		{
+			name:    "PostgreSQL placeholders in quoted literals",
+			dialect: new(qb.PostgreSQLDialect),
+			build: func(db *DBModel) (string, []any) {
+				price := db.expression(Expr("price > ?", 5))
+
+				return `SELECT '$1' AS label, "$2" FROM items WHERE ` + price + " AND id = $1", []any{1}
+			},
+			expected:     `SELECT '$1' AS label, "$2" FROM items WHERE price > $1 AND id = $2`,
+			expectedArgs: []any{5, 1},
+		},
+		{
+			name:    "MySQL placeholders in quoted literals",
+			dialect: new(qb.MySQLDialect),
+			build: func(db *DBModel) (string, []any) {
+				views := db.expression(Expr("views + ?", 2))
+
+				return "SELECT 'it''s ?', 'it\\'s ?', `?` FROM posts WHERE views = " + views + " AND id = ?", []any{9}
+			},
+			expected:     "SELECT 'it''s ?', 'it\\'s ?', `?` FROM posts WHERE views = views + ? AND id = ?",
+			expectedArgs: []any{2, 9},
+		},
+		{
+			name:    "quoted literals in expressions",
+			dialect: new(qb.PostgreSQLDialect),
+			build: func(db *DBModel) (string, []any) {
+				name := db.expression(Expr("COALESCE(name, '?') || ?", "!"))
+
+				return "SELECT " + name + " FROM users", nil
+			},
+			expected:     "SELECT COALESCE(name, '?') || $1 FROM users",
+			expectedArgs: []any{"!"},
+		},
+		{
+			name:    "PostgreSQL jsonb operators in expressions",
+			dialect: new(qb.PostgreSQLDialect),
+			build: func(db *DBModel) (string, []any) {
+				key := db.expression(Expr("data ?? ?", "a"))
+				anyKey := db.expression(Expr("data ?| ?", "{b,c}"))
+				allKeys := db.expression(Expr("data ?& ?", "{d,e}"))
+
+				return "SELECT * FROM docs WHERE " + key + " AND " + anyKey + " AND " + allKeys + " AND id = $1", []any{1}
+			},
+			expected:     "SELECT * FROM docs WHERE data ? $1 AND data ?| $2 AND data ?& $3 AND id = $4",
+			expectedArgs: []any{"a", "{b,c}", "{d,e}", 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useDialect(t, tt.dialect)
+
+			db := Instance()
+			sqlStr, args := tt.build(db)
+
+			result, resultArgs, err := db.bindExpressions(sqlStr, args)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result != tt.expected {
+				t.Errorf("bindExpressions() = %q, expected %q", result, tt.expected)
+			}
+
+			if !reflect.DeepEqual(resultArgs, tt.expectedArgs) {
+				t.Errorf("bindExpressions() args = %v, expected %v", resultArgs, tt.expectedArgs)
+			}
+		})
+	}
+}
+
+func TestBindExpressionsErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		sqlStr string
+	}{
+		{
+			name:   "unknown expression",
+			sqlStr: "SELECT * FROM users WHERE id IN (SELECT " + expressionMarker + "0" + expressionMarker + ")",
+		},
+		{
+			name:   "unterminated marker",
+			sqlStr: "SELECT * FROM users WHERE name = " + expressionMarker,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useDialect(t, new(qb.PostgreSQLDialect))
+
+			if _, _, err := Instance().bindExpressions(tt.sqlStr, nil); err == nil {
+				t.Errorf("bindExpressions(%q) expected an error", tt.sqlStr)
+			}
+		})
+	}
+}
+
+func TestGroupByExpr(t *testing.T) {
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect))
+
+	var posts []unionPost
+	_, err := Instance().
+		Select(Expr("DATE_TRUNC(?, created_at) AS day", "day"), "COUNT(*) AS total").
+		Where("status", Eq, "active").
+		GroupBy("status").
+		GroupByExpr(Expr("DATE_TRUNC(?, created_at)", "day")).
+		WithoutCount().
+		Find(&posts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "SELECT DATE_TRUNC($1, created_at) AS day, COUNT(*) AS total FROM posts " +
+		"WHERE status = $2 GROUP BY status, DATE_TRUNC($3, created_at)"
+	if len(fake.statements) != 1 || fake.statements[0] != expected {
+		t.Errorf("statements = %q, expected %q", fake.statements, expected)
+	}
+
+	expectedArgs := []driver.Value{"day", "active", "day"}
+	if !reflect.DeepEqual(fake.args[0], expectedArgs) {
+		t.Errorf("args = %v, expected %v", fake.args[0], expectedArgs)
+	}
+}
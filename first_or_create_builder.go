@@ -0,0 +1,301 @@
+package db
+
+import (
+	"database/sql"
+	"github.com/gflydev/core/errors"
+	qb "github.com/jivegroup/fluentsql"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ====================================================================
+//                     Find or create a record
+// ====================================================================
+
+// FirstOrInit retrieves the first record matching the conditions of the query and the non-zero
+// fields of the model. When no record matches, the model is initialized with the equality
+// conditions of the query and the attributes, without being saved.
+//
+// Parameters:
+//   - model (any): A pointer to the model receiving the record.
+//   - attrs (map[string]any): The values assigned when no record matches, by column or field name. Can be nil.
+//
+// Returns:
+//   - error: An error object if any issues occur during the query; nil otherwise.
+//
+// Example:
+//
+//	var user User
+//	err := mb.Instance().Where("email", mb.Eq, email).FirstOrInit(&user, map[string]any{"status": "pending"})
+//	// When not found: user.Email = email, user.Status = "pending", user.ID = 0
+func (db *DBModel) FirstOrInit(model any, attrs map[string]any) error {
+	// Reset fluent model builder, also when an error stops the operation
+	defer db.reset()
+
+	if !isStructPointer(model) {
+		return errors.New("Invalid data :: model not *Struct type")
+	}
+
+	conditions := db.equalityConditions()
+
+	err := db.First(model)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	return assignAttributes(model, conditions, attrs)
+}
+
+// FirstOrCreate retrieves the first record matching the conditions of the query and the non-zero
+// fields of the model. When no record matches, the model is created with the equality conditions
+// of the query and the attributes, and its serial primary key is set.
+// The insertion skips unique conflicts (see OnConflict and DoNothing): when a concurrent request created the record first,
+// the record is retrieved again instead of failing with a duplicate key error. A unique
+// index on the columns of the conditions is required to prevent duplicates. PostgreSQL and SQLite
+// use it as conflict target, e.g. `ON CONFLICT (email) DO NOTHING`.
+//
+// Parameters:
+//   - model (any): A pointer to the model receiving the record.
+//   - attrs (map[string]any): The values assigned when creating the record, by column or field name. Can be nil.
+//
+// Returns:
+//   - error: An error object if any issues occur during the queries; nil otherwise.
+//
+// Example:
+//
+//	var user User
+//	err := mb.Instance().Where("email", mb.Eq, email).FirstOrCreate(&user, map[string]any{"status": "pending"})
+func (db *DBModel) FirstOrCreate(model any, attrs map[string]any) error {
+	// Reset fluent model builder, also when an error stops the operation
+	defer db.reset()
+
+	if !isStructPointer(model) {
+		return errors.New("Invalid data :: model not *Struct type")
+	}
+
+	conditions := db.equalityConditions()
+
+	// Each step restarts from the query and the model, which the operations change
+	state := *db
+	original := reflect.ValueOf(model).Elem().Interface()
+
+	err := db.First(model)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	if err = assignAttributes(model, conditions, attrs); err != nil {
+		return err
+	}
+
+	*db = state
+
+	var created bool
+	if created, err = db.createOrSkip(model, slices.Sorted(maps.Keys(conditions))); err != nil || created {
+		return err
+	}
+
+	// Created concurrently: retrieve the record
+	*db = state
+	reflect.ValueOf(model).Elem().Set(reflect.ValueOf(original))
+
+	if err = db.First(model); errors.Is(err, sql.ErrNoRows) {
+		return errors.New("FirstOrCreate :: the record conflicts with a record not matching the conditions")
+	}
+
+	return err
+}
+
+// UpdateOrCreate updates the record matching the given columns with the values, or creates it
+// with the matching columns and the values. The model of the query (see Model) receives the
+// record. The operation runs in a transaction (the current one, or its own) and locks the
+// record, and the insertion skips unique conflicts so that concurrent requests update the
+// record created first. A unique index on the matching columns is required to prevent duplicates.
+// PostgreSQL and SQLite use it as conflict target, e.g. `ON CONFLICT (key, user_id) DO NOTHING`.
+//
+// Parameters:
+//   - match (map[string]any): The columns identifying the record, by column name.
+//   - values (map[string]any): The values to update or create, by column or field name.
+//
+// Returns:
+//   - error: An error object if any issues occur during the queries; nil otherwise.
+//
+// Example:
+//
+//	var setting Setting
+//	err := mb.Instance().Model(&setting).UpdateOrCreate(
+//	    map[string]any{"user_id": userID, "key": "theme"},
+//	    map[string]any{"value": "dark"},
+//	)
+func (db *DBModel) UpdateOrCreate(match, values map[string]any) (err error) {
+	// Reset fluent model builder, also when an error stops the operation
+	defer db.reset()
+
+	model := db.model
+	if !isStructPointer(model) {
+		return errors.New("Invalid data :: Model must be a *Struct")
+	}
+
+	// Run in a transaction
+	if db.tx == nil {
+		db.Begin()
+
+		defer func() {
+			if err != nil {
+				_ = db.Rollback()
+			} else {
+				err = db.Commit()
+			}
+
+			db.tx = nil
+		}()
+	}
+
+	// Each step restarts from the query and the model, which the operations change
+	for column, value := range match {
+		db.Where(column, Eq, value)
+	}
+	state := *db
+	original := reflect.ValueOf(model).Elem().Interface()
+
+	// Retrieve and lock the record
+	err = db.LockForUpdate().First(model)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		if err = assignAttributes(model, match, values); err != nil {
+			return err
+		}
+
+		*db = state
+
+		var created bool
+		if created, err = db.createOrSkip(model, slices.Sorted(maps.Keys(match))); err != nil || created {
+			return err
+		}
+
+		// Created concurrently: retrieve and lock the record
+		*db = state
+		reflect.ValueOf(model).Elem().Set(reflect.ValueOf(original))
+
+		if err = db.LockForUpdate().First(model); err != nil {
+			return err
+		}
+	}
+
+	if err = assignAttributes(model, nil, values); err != nil {
+		return err
+	}
+
+	// Update by primary key
+	return db.Update(model)
+}
+
+// createOrSkip creates the model unless it conflicts with an existing record on the unique index of
+// the columns. On PostgreSQL and SQLite, conflicts on other unique indexes fail instead of being skipped,
+// because the record matching the columns would not be found. MySQL skips conflicts on every unique index.
+//
+// Parameters:
+//   - model (any): A pointer to the model to create.
+//   - columns ([]string): The columns of the unique index (conflict target). Empty to skip any conflict.
+//
+// Returns:
+//   - bool: True if the record was created, false if it conflicts with an existing record.
+//   - error: An error object if any issues occur during the insertion; nil otherwise.
+func (db *DBModel) createOrSkip(model any, columns []string) (bool, error) {
+	db.OnConflict(Conflict{Columns: columns, DoNothing: true})
+
+	db.rowsAffected = 0
+
+	if err := db.Create(model); err != nil {
+		return false, err
+	}
+
+	return db.rowsAffected > 0, nil
+}
+
+// equalityConditions returns the values of the equality conditions of the query by column name.
+// Nothing is returned when the conditions are combined with OR, because the record may not
+// satisfy all of them.
+//
+// Returns:
+//   - map[string]any: The values of the equality conditions.
+func (db *DBModel) equalityConditions() map[string]any {
+	values := make(map[string]any)
+
+	for _, condition := range db.whereStatement.Conditions {
+		if condition.AndOr == Or {
+			return nil
+		}
+
+		field, ok := condition.Field.(string)
+		if !ok || condition.Opt != Eq || len(condition.Group) > 0 {
+			continue
+		}
+
+		// Columns and expressions are not values
+		switch condition.Value.(type) {
+		case ValueField, qb.ValueField:
+			continue
+		}
+
+		values[field[strings.LastIndex(field, ".")+1:]] = condition.Value
+	}
+
+	return values
+}
+
+// assignAttributes assigns values to the fields of a model, by column or field name.
+//
+// Parameters:
+//   - model (any): A pointer to the model.
+//   - maps (...map[string]any): The values to assign, in order. Later maps overwrite earlier ones.
+//
+// Returns:
+//   - error: An error if a key matches no field or a value can't be assigned.
+func assignAttributes(model any, maps ...map[string]any) error {
+	table, err := ModelData(model)
+	if err != nil {
+		return err
+	}
+
+	// Field names by column name
+	keys := make(map[string]string, len(table.Columns))
+	for _, column := range table.Columns {
+		keys[column.Name] = column.Key
+	}
+
+	structValue := reflect.ValueOf(model).Elem()
+
+	for _, values := range maps {
+		for name, value := range values {
+			key, ok := keys[name]
+			if !ok {
+				key = name
+			}
+
+			field := structValue.FieldByName(key)
+			if !field.IsValid() || !field.CanSet() {
+				return errors.New("Invalid attribute %s :: no field of %s", name, structValue.Type())
+			}
+
+			data := reflect.ValueOf(value)
+			switch {
+			case !data.IsValid():
+				field.Set(reflect.Zero(field.Type()))
+			case data.Type().AssignableTo(field.Type()):
+				field.Set(data)
+			default:
+				if err = setValue(model, key, value); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
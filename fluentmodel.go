@@ -1,13 +1,14 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
 	"github.com/gflydev/core/errors"
 	"github.com/gflydev/core/log"
 	"github.com/gflydev/core/utils"
 	qb "github.com/jivegroup/fluentsql"
 	"github.com/jmoiron/sqlx"
-	"reflect"
 )
 
 // ====================================================================
@@ -65,6 +66,9 @@ type Raw struct {
 //     Used for ORM operations to determine table name, column mappings, and data types.
 //     Should be a struct or pointer to struct with appropriate database tags.
 //
+//   - table (string): The table name set by Table. It replaces the table of the model, or makes
+//     the query schemaless when no model is set.
+//
 //   - raw (Raw): Container for raw SQL queries and their parameters.
 //     When populated, takes precedence over query builder operations.
 //     Allows execution of custom SQL that bypasses the ORM query construction.
@@ -105,6 +109,32 @@ type Raw struct {
 //     Alternative to LIMIT for databases that support FETCH FIRST/NEXT syntax.
 //     Provides SQL standard-compliant result set limiting.
 //
+//   - unionStatement (Union): Queries combined by UNION, UNION ALL, INTERSECT or EXCEPT.
+//     When present, ORDER BY, LIMIT and FETCH apply to the combined result.
+//
+//   - setStatement (qb.UpdateSet): Explicit SET items for Update operations.
+//     Values can be plain values or expressions such as Expr("views + 1").
+//
+//   - lockStatement (Lock): Row locking clause appended to SELECT operations.
+//     Supports FOR UPDATE, FOR SHARE, OF tables, NOWAIT and SKIP LOCKED.
+//
+//   - rowsAffected (int64): Number of rows affected by the last UPDATE or DELETE statement.
+//     It is kept after the builder is reset.
+//
+//   - globalScopes (globalScopeState): Global scopes disabled for the query and whether
+//     the global scopes of the model were already applied.
+//
+//   - softDelete (softDeleteState): How soft deleted rows are handled (WithTrashed, OnlyTrashed)
+//     and whether Delete removes the rows (ForceDelete).
+//
+//   - returning (returningState): Columns of the rows affected by Update and Delete scanned back
+//     into their model (Returning).
+//
+//   - strict (bool): Whether Update and Delete fail with ErrNoRowsAffected when no row is affected.
+//
+//   - expressions (map[string]Expression): Expressions with bindings used in the query.
+//     Their arguments are placed among the query arguments when the SQL is executed.
+//
 // Usage Patterns:
 //
 //	// Basic query building
@@ -133,20 +163,35 @@ type Raw struct {
 //   - Raw SQL takes precedence over query builder operations when both are present
 //   - The struct is designed for method chaining to create fluent, readable database code
 type DBModel struct {
-	tx *sqlx.Tx // Database transaction context for atomic operations
-
-	model any // Target model struct defining table structure and column mappings
-	raw   Raw // Raw SQL query container with parameters for custom query execution
-
-	selectStatement      qb.Select  // SELECT clause builder for column specification and result shaping
-	omitsSelectStatement qb.Select  // Column omission builder for excluding specific fields from results
-	whereStatement       qb.Where   // WHERE clause builder for filtering conditions and logical operations
-	joinStatement        qb.Join    // JOIN clause builder for multi-table relational queries
-	groupByStatement     qb.GroupBy // GROUP BY clause builder for result aggregation and organization
-	havingStatement      qb.Having  // HAVING clause builder for post-aggregation filtering
-	orderByStatement     qb.OrderBy // ORDER BY clause builder for result sorting and ordering
-	limitStatement       qb.Limit   // LIMIT clause builder for result set size control and pagination
-	fetchStatement       qb.Fetch   // FETCH clause builder for SQL standard-compliant result limiting
+	tx   *sqlx.Tx   // Database transaction context for atomic operations
+	conn *sqlx.Conn // Dedicated connection running the transaction (see BeginConn)
+
+	model any    // Target model struct defining table structure and column mappings
+	table string // Table name replacing the model's table, or used without model (schemaless)
+	raw   Raw    // Raw SQL query container with parameters for custom query execution
+
+	selectStatement      qb.Select    // SELECT clause builder for column specification and result shaping
+	omitsSelectStatement qb.Select    // Column omission builder for excluding specific fields from results
+	whereStatement       qb.Where     // WHERE clause builder for filtering conditions and logical operations
+	joinStatement        qb.Join      // JOIN clause builder for multi-table relational queries
+	groupByStatement     qb.GroupBy   // GROUP BY clause builder for result aggregation and organization
+	havingStatement      qb.Having    // HAVING clause builder for post-aggregation filtering
+	orderByStatement     qb.OrderBy   // ORDER BY clause builder for result sorting and ordering
+	limitStatement       qb.Limit     // LIMIT clause builder for result set size control and pagination
+	fetchStatement       qb.Fetch     // FETCH clause builder for SQL standard-compliant result limiting
+	unionStatement       Union        // Set operations (UNION, INTERSECT, EXCEPT) combining other queries
+	setStatement         qb.UpdateSet // SET clause items applied by Update in addition to the model's columns
+	lockStatement        Lock         // Row locking clause (FOR UPDATE, FOR SHARE) for SELECT operations
+
+	rowsAffected   int64                 // Number of rows affected by the last INSERT, UPDATE or DELETE statement
+	conflict       *Conflict             // Handling of unique conflicts of INSERT statements (upsert)
+	countStrategy  CountStrategy         // Strategy computing the total number of rows of Find
+	sampleStrategy SampleStrategy        // Strategy sampling random rows of TakeOne and TakeN
+	globalScopes   globalScopeState      // Global scopes options of the query
+	softDelete     softDeleteState       // Soft delete options of the query
+	returning      returningState        // Rows returned by UPDATE and DELETE statements
+	strict         bool                  // Whether UPDATE and DELETE statements must affect rows
+	expressions    map[string]Expression // Expressions with bindings registered by their marker in the query
 }
 
 // Instance creates and returns a new DBModel instance for database operations.
@@ -225,6 +270,7 @@ func Instance() *DBModel {
 //	*DBModel - The reset DBModel instance.
 func (db *DBModel) reset() *DBModel {
 	db.model = nil                                   // Clear the model.
+	db.table = ""                                    // Clear the table name.
 	db.raw.sqlStr = ""                               // Reset raw SQL string.
 	db.selectStatement.Columns = []any{}             // Clear SELECT columns.
 	db.omitsSelectStatement.Columns = []any{}        // Clear omitted SELECT columns.
@@ -235,6 +281,17 @@ func (db *DBModel) reset() *DBModel {
 	db.orderByStatement.Items = []qb.SortItem{}      // Clear ORDER BY items.
 	db.limitStatement.Limit = 0                      // Reset limit.
 	db.fetchStatement.Fetch = 0                      // Reset fetch.
+	db.unionStatement.Items = []UnionItem{}          // Clear set operations.
+	db.setStatement.Items = []qb.UpdateItem{}        // Clear SET items.
+	db.lockStatement = Lock{}                        // Clear row locking clause.
+	db.globalScopes = globalScopeState{}             // Clear global scopes options.
+	db.softDelete = softDeleteState{}                // Clear soft delete options.
+	db.returning = returningState{}                  // Clear returned columns.
+	db.strict = false                                // Disable strict mode.
+	db.countStrategy = CountExact                    // Restore the default count strategy.
+	db.sampleStrategy = SampleRandomOrder            // Restore the default sample strategy.
+	db.conflict = nil                                // Clear conflict handling.
+	db.expressions = nil                             // Clear registered expressions.
 
 	return db
 }
@@ -270,14 +327,33 @@ func (db *DBModel) get(q *qb.QueryBuilder, model any) (err error) {
 // Returns:
 //   - err (error): Error encountered during execution, if any.
 func (db *DBModel) getRaw(sqlStr string, args []any, model any) (err error) {
+	return db.getRawContext(context.Background(), sqlStr, args, model)
+}
+
+// getRawContext executes a raw SQL query to fetch a single data row with a context.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the query (cancellation, deadline).
+//   - sqlStr (string): The raw SQL query string.
+//   - args ([]any): Arguments for the query placeholders.
+//   - model (any): The model to map the resulting row.
+//
+// Returns:
+//   - err (error): Error encountered during execution, if any.
+func (db *DBModel) getRawContext(ctx context.Context, sqlStr string, args []any, model any) (err error) {
+	// Place expressions and their bindings
+	if sqlStr, args, err = db.bindExpressions(sqlStr, args); err != nil {
+		return
+	}
+
 	if utils.Getenv("DB_DEBUG", false) {
 		log.Infof("SQL> %s - args %v", sqlStr, args)
 	}
 
 	if db.tx != nil {
-		err = db.tx.Get(model, sqlStr, args...)
+		err = db.tx.GetContext(ctx, model, sqlStr, args...)
 	} else {
-		err = dbInstance.Get(model, sqlStr, args...)
+		err = dbInstance.GetContext(ctx, model, sqlStr, args...)
 	}
 
 	return
@@ -310,14 +386,83 @@ func (db *DBModel) query(q *qb.QueryBuilder, model any) (err error) {
 // Returns:
 //   - err (error): Error encountered during execution, if any.
 func (db *DBModel) queryRaw(sqlStr string, args []any, model any) (err error) {
+	return db.queryRawContext(context.Background(), sqlStr, args, model)
+}
+
+// queryRawContext executes a raw SQL query to fetch a list of data rows with a context.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the query (cancellation, deadline).
+//   - sqlStr (string): The raw SQL query string.
+//   - args ([]any): Arguments for the query placeholders.
+//   - model (any): The model to map the resulting rows.
+//
+// Returns:
+//   - err (error): Error encountered during execution, if any.
+func (db *DBModel) queryRawContext(ctx context.Context, sqlStr string, args []any, model any) (err error) {
+	// Place expressions and their bindings
+	if sqlStr, args, err = db.bindExpressions(sqlStr, args); err != nil {
+		return
+	}
+
 	if utils.Getenv("DB_DEBUG", false) {
 		log.Infof("SQL> %s - args %v", sqlStr, args)
 	}
 
+	// Rows as maps (schemaless)
+	if isMapSlice(model) {
+		var rows *sqlx.Rows
+		if db.tx != nil {
+			rows, err = db.tx.QueryxContext(ctx, sqlStr, args...)
+		} else {
+			rows, err = dbInstance.QueryxContext(ctx, sqlStr, args...)
+		}
+		if err != nil {
+			return
+		}
+		defer func() {
+			_ = rows.Close()
+		}()
+
+		_, err = scanMaps(rows, model)
+
+		return
+	}
+
 	if db.tx != nil {
-		err = db.tx.Select(model, sqlStr, args...)
+		err = db.tx.SelectContext(ctx, model, sqlStr, args...)
 	} else {
-		err = dbInstance.Select(model, sqlStr, args...)
+		err = dbInstance.SelectContext(ctx, model, sqlStr, args...)
+	}
+
+	return
+}
+
+// rowsRaw executes a raw SQL query and returns the rows without reading them.
+// The caller must close the rows.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the query (cancellation, deadline).
+//   - sqlStr (string): The raw SQL query string.
+//   - args ([]any): Arguments for the query placeholders.
+//
+// Returns:
+//   - rows (*sqlx.Rows): The result rows.
+//   - err (error): Error encountered during execution, if any.
+func (db *DBModel) rowsRaw(ctx context.Context, sqlStr string, args []any) (rows *sqlx.Rows, err error) {
+	// Place expressions and their bindings
+	if sqlStr, args, err = db.bindExpressions(sqlStr, args); err != nil {
+		return
+	}
+
+	if utils.Getenv("DB_DEBUG", false) {
+		log.Infof("SQL> %s - args %v", sqlStr, args)
+	}
+
+	if db.tx != nil {
+		rows, err = db.tx.QueryxContext(ctx, sqlStr, args...)
+	} else {
+		rows, err = dbInstance.QueryxContext(ctx, sqlStr, args...)
 	}
 
 	return
@@ -327,7 +472,8 @@ func (db *DBModel) queryRaw(sqlStr string, args []any, model any) (err error) {
 //
 // Parameters:
 //   - q (*qb.InsertBuilder): The insert query builder with the SQL and arguments.
-//   - primaryColumn (*Column): The primary column to return, used for PostgreSQL.
+//   - primaryColumn (*Column): The primary column to return: with RETURNING for PostgreSQL and SQLite,
+//     and LastInsertId for MySQL.
 //
 // Returns:
 //   - id (any): The ID of the newly inserted row.
@@ -346,29 +492,43 @@ func (db *DBModel) add(q *qb.InsertBuilder, primaryColumn *Column) (id any, err
 // Parameters:
 //   - sqlStr (string): The raw SQL insert query string.
 //   - args ([]any): Arguments for the query placeholders.
-//   - primaryColumn (*Column): The primary column to return, used for PostgreSQL.
+//   - primaryColumn (*Column): The primary column to return: with RETURNING for PostgreSQL and SQLite,
+//     and LastInsertId for MySQL.
 //
 // Returns:
 //   - id (any): The ID of the newly inserted row.
 //   - err (error): Error encountered during execution, if any.
 func (db *DBModel) addRaw(sqlStr string, args []any, primaryColumn *Column) (id any, err error) {
+	// Place expressions and their bindings
+	if sqlStr, args, err = db.bindExpressions(sqlStr, args); err != nil {
+		return
+	}
+
 	if utils.Getenv("DB_DEBUG", false) {
 		log.Infof("SQL> %s - args %v", sqlStr, args)
 	}
 
+	var result sql.Result
+	db.rowsAffected = 0
+
 	// If no primaryColumn is provided, we don't need to retrieve the ID
 	if primaryColumn == nil {
 		// Just execute the query without returning an ID
 		if db.tx != nil {
-			_, err = db.tx.Exec(sqlStr, args...)
+			result, err = db.tx.Exec(sqlStr, args...)
 		} else {
-			_, err = dbInstance.Exec(sqlStr, args...)
+			result, err = dbInstance.Exec(sqlStr, args...)
 		}
+
+		if err == nil {
+			db.rowsAffected, err = result.RowsAffected()
+		}
+
 		return nil, err
 	}
 
 	// Data persistence
-	if qb.IsDialect(qb.PostgreSQL) {
+	if qb.IsDialect(qb.PostgreSQL) || qb.IsDialect(qb.SQLite) {
 		sqlStr += " RETURNING " + primaryColumn.Name
 
 		if utils.Getenv("DB_DEBUG", false) {
@@ -380,12 +540,32 @@ func (db *DBModel) addRaw(sqlStr string, args []any, primaryColumn *Column) (id
 		} else {
 			err = dbInstance.QueryRow(sqlStr, args...).Scan(&id)
 		}
+
+		switch {
+		case err == nil:
+			db.rowsAffected = 1
+		case errors.Is(err, sql.ErrNoRows) && db.conflict != nil:
+			// No row is returned when the conflict clause skips the insertion
+			err = nil
+		}
 	} else if qb.IsDialect(qb.MySQL) {
-		var result sql.Result
 		if db.tx != nil {
-			result, _ = db.tx.Exec(sqlStr, args...)
+			result, err = db.tx.Exec(sqlStr, args...)
 		} else {
-			result, _ = dbInstance.Exec(sqlStr, args...)
+			result, err = dbInstance.Exec(sqlStr, args...)
+		}
+
+		if err != nil {
+			return
+		}
+
+		if db.rowsAffected, err = result.RowsAffected(); err != nil {
+			return
+		}
+
+		// No ID when INSERT IGNORE skips the insertion
+		if db.rowsAffected == 0 && db.conflict != nil && db.conflict.DoNothing {
+			return
 		}
 
 		id, err = result.LastInsertId()
@@ -394,6 +574,86 @@ func (db *DBModel) addRaw(sqlStr string, args []any, primaryColumn *Column) (id
 	return
 }
 
+// addRows executes a multi-row insertion query and returns the inserted IDs, in row order.
+//
+// Parameters:
+//   - sqlStr (string): The insertion query string.
+//   - args ([]any): Arguments for the query placeholders.
+//   - primaryColumn (*Column): The serial primary column to return. Nil to skip the IDs.
+//   - count (int): The number of inserted rows.
+//
+// Returns:
+//   - ids ([]any): The IDs of the inserted rows. Nil when they are unknown.
+//   - err (error): Error encountered during execution, if any.
+func (db *DBModel) addRows(sqlStr string, args []any, primaryColumn *Column, count int) (ids []any, err error) {
+	db.rowsAffected = 0
+
+	// Return the IDs of the inserted rows
+	if primaryColumn != nil && (qb.IsDialect(qb.PostgreSQL) || qb.IsDialect(qb.SQLite)) {
+		var rows *sqlx.Rows
+		if rows, err = db.rowsRaw(context.Background(), sqlStr+" RETURNING "+primaryColumn.Name, args); err != nil {
+			return
+		}
+		defer func() {
+			_ = rows.Close()
+		}()
+
+		for rows.Next() {
+			var id any
+			if err = rows.Scan(&id); err != nil {
+				return
+			}
+
+			ids = append(ids, id)
+		}
+
+		db.rowsAffected = int64(len(ids))
+		err = rows.Err()
+
+		return
+	}
+
+	// Place expressions and their bindings
+	if sqlStr, args, err = db.bindExpressions(sqlStr, args); err != nil {
+		return
+	}
+
+	if utils.Getenv("DB_DEBUG", false) {
+		log.Infof("SQL> %s - args %v", sqlStr, args)
+	}
+
+	var result sql.Result
+	if db.tx != nil {
+		result, err = db.tx.Exec(sqlStr, args...)
+	} else {
+		result, err = dbInstance.Exec(sqlStr, args...)
+	}
+
+	if err != nil {
+		return
+	}
+
+	if db.rowsAffected, err = result.RowsAffected(); err != nil {
+		return
+	}
+
+	// MySQL gives the ID of the first row, the next rows have consecutive IDs.
+	// Upserts don't insert all rows, so their IDs are unknown.
+	if primaryColumn != nil && qb.IsDialect(qb.MySQL) && db.conflict == nil {
+		var firstID int64
+		if firstID, err = result.LastInsertId(); err != nil {
+			return
+		}
+
+		ids = make([]any, count)
+		for i := range ids {
+			ids[i] = firstID + int64(i)
+		}
+	}
+
+	return
+}
+
 // update performs updating data using UpdateBuilder.
 //
 // Parameters:
@@ -435,15 +695,27 @@ func (db *DBModel) delete(q *qb.DeleteBuilder) (err error) {
 // Returns:
 //   - err (error): Error encountered during execution, if any.
 func (db *DBModel) execRaw(sqlStr string, args []any) (err error) {
+	// Place expressions and their bindings
+	if sqlStr, args, err = db.bindExpressions(sqlStr, args); err != nil {
+		return
+	}
+
 	if utils.Getenv("DB_DEBUG", false) {
 		log.Infof("SQL> %s - args %v", sqlStr, args)
 	}
 
 	// Data persistence
+	var result sql.Result
 	if db.tx != nil {
-		_, err = db.tx.Exec(sqlStr, args...)
+		result, err = db.tx.Exec(sqlStr, args...)
 	} else {
-		_, err = dbInstance.Exec(sqlStr, args...)
+		result, err = dbInstance.Exec(sqlStr, args...)
+	}
+
+	// Keep the number of rows affected by the statement
+	db.rowsAffected = 0
+	if err == nil {
+		db.rowsAffected, _ = result.RowsAffected()
 	}
 
 	return
@@ -452,12 +724,13 @@ func (db *DBModel) execRaw(sqlStr string, args []any) (err error) {
 // count retrieves the total number of rows based on the QueryBuilder.
 //
 // Parameters:
+//   - ctx (context.Context): The context of the query (cancellation, deadline).
 //   - q (*qb.QueryBuilder): The query builder with the SQL and arguments.
 //   - total (*int): Pointer to an integer to store the total count.
 //
 // Returns:
 //   - err (error): Error encountered during execution, if any.
-func (db *DBModel) count(q *qb.QueryBuilder, total *int) error {
+func (db *DBModel) count(ctx context.Context, q *qb.QueryBuilder, total *int) error {
 	var fetch qb.Fetch
 	var limit qb.Limit
 
@@ -470,7 +743,9 @@ func (db *DBModel) count(q *qb.QueryBuilder, total *int) error {
 		Select("COUNT(*) AS total").
 		From(q, "_result_out_")
 
-	err := db.get(sqlBuilderCount, total)
+	sqlStr, args, _ := sqlBuilderCount.Sql()
+
+	err := db.getRawContext(ctx, sqlStr, args, total)
 	if err != nil {
 		return err
 	}
@@ -580,11 +855,15 @@ func (db *DBModel) Raw(sqlStr string, args ...any) *DBModel {
 //
 // Parameters:
 //   - columns (...any): Variadic list of columns to include in the SELECT clause.
+//     A column can be a string or an Expression with bindings.
 //
 // Returns:
 //   - *DBModel: A reference to the DBModel instance for chaining.
 func (db *DBModel) Select(columns ...any) *DBModel {
-	db.selectStatement.Columns = columns
+	db.selectStatement.Columns = make([]any, 0, len(columns))
+	for _, column := range columns {
+		db.selectStatement.Columns = append(db.selectStatement.Columns, db.expressionField(column))
+	}
 
 	return db
 }
@@ -713,12 +992,12 @@ func (db *DBModel) Model(model any) *DBModel {
 // Returns:
 //   - *DBModel: A reference to the DBModel instance for chaining.
 func (db *DBModel) Where(field any, opt WhereOpt, value any) *DBModel {
-	db.whereStatement.Append(qb.Condition{
+	db.whereStatement.Append(db.expressionCondition(qb.Condition{
 		Field: field,
 		Opt:   opt,
 		Value: value,
 		AndOr: And,
-	})
+	}))
 
 	return db
 }
@@ -733,12 +1012,12 @@ func (db *DBModel) Where(field any, opt WhereOpt, value any) *DBModel {
 // Returns:
 //   - *DBModel: A reference to the DBModel instance for chaining.
 func (db *DBModel) WhereOr(field any, opt WhereOpt, value any) *DBModel {
-	db.whereStatement.Append(qb.Condition{
+	db.whereStatement.Append(db.expressionCondition(qb.Condition{
 		Field: field,
 		Opt:   opt,
 		Value: value,
 		AndOr: Or,
-	})
+	}))
 
 	return db
 }
@@ -757,7 +1036,7 @@ func (db *DBModel) WhereGroup(groupCondition FnWhereBuilder) *DBModel {
 	// Convert local conditions to qb.Condition types for the group
 	var qbConditions []qb.Condition
 	for _, localCondition := range whereBuilder.Conditions() {
-		qbConditions = append(qbConditions, localCondition.ToQBCondition())
+		qbConditions = append(qbConditions, db.expressionCondition(localCondition.ToQBCondition()))
 	}
 
 	// Create a qb.Condition with the group
@@ -788,7 +1067,7 @@ func (db *DBModel) When(condition bool, groupCondition FnWhereBuilder) *DBModel
 
 	// Convert local Condition types to qb.Condition types
 	for _, localCondition := range whereBuilder.Conditions() {
-		db.whereStatement.Conditions = append(db.whereStatement.Conditions, localCondition.ToQBCondition())
+		db.whereStatement.Conditions = append(db.whereStatement.Conditions, db.expressionCondition(localCondition.ToQBCondition()))
 	}
 
 	return db
@@ -807,7 +1086,7 @@ func (db *DBModel) Join(join JoinType, table string, condition Condition) *DBMod
 	db.joinStatement.Append(qb.JoinItem{
 		Join:      join,
 		Table:     table,
-		Condition: condition.ToQBCondition(),
+		Condition: db.expressionCondition(condition.ToQBCondition()),
 	})
 
 	return db
@@ -823,12 +1102,12 @@ func (db *DBModel) Join(join JoinType, table string, condition Condition) *DBMod
 // Returns:
 //   - *DBModel: A reference to the DBModel instance for chaining.
 func (db *DBModel) Having(field any, opt WhereOpt, value any) *DBModel {
-	db.havingStatement.Append(qb.Condition{
+	db.havingStatement.Append(db.expressionCondition(qb.Condition{
 		Field: field,
 		Opt:   opt,
 		Value: value,
 		AndOr: And,
-	})
+	}))
 
 	return db
 }
@@ -846,16 +1125,55 @@ func (db *DBModel) GroupBy(fields ...string) *DBModel {
 	return db
 }
 
+// GroupByExpr adds GROUP BY expressions to the query.
+//
+// Parameters:
+//   - expressions (...Expression): The expressions to group by.
+//
+// Returns:
+//   - *DBModel: A reference to the DBModel instance for chaining.
+//
+// Example:
+//
+//	db.Select(mb.Expr("DATE_TRUNC(?, created_at) AS day", "day"), "COUNT(*) AS total").
+//	    GroupByExpr(mb.Expr("DATE_TRUNC(?, created_at)", "day"))
+func (db *DBModel) GroupByExpr(expressions ...Expression) *DBModel {
+	for _, e := range expressions {
+		db.groupByStatement.Append(db.expression(e))
+	}
+
+	return db
+}
+
 // OrderBy adds an ORDER BY clause to the query.
 //
 // Parameters:
-//   - field (string): The field to sort by.
+//   - field (any): The field to sort by. It can be a string or an Expression.
 //   - dir (qb.OrderByDir): The sorting direction (e.g., ASC or DESC).
 //
 // Returns:
 //   - *DBModel: A reference to the DBModel instance for chaining.
-func (db *DBModel) OrderBy(field string, dir OrderByDir) *DBModel {
-	db.orderByStatement.Append(field, dir)
+func (db *DBModel) OrderBy(field any, dir OrderByDir) *DBModel {
+	db.orderByStatement.Append(fmt.Sprint(db.expressionField(field)), dir)
+
+	return db
+}
+
+// Set adds a SET item applied by Update in addition to the model's columns.
+// An explicit SET item takes precedence over the model's value of the same column.
+//
+// Parameters:
+//   - field (string): The column to update.
+//   - value (any): The new value. It can be a plain value or an Expression.
+//
+// Returns:
+//   - *DBModel: A reference to the DBModel instance for chaining.
+//
+// Example:
+//
+//	err := db.Set("views", mb.Expr("views + ?", 1)).Update(&post)
+func (db *DBModel) Set(field string, value any) *DBModel {
+	db.setStatement.Append(field, db.expressionValue(value))
 
 	return db
 }
@@ -955,6 +1273,39 @@ func (db *DBModel) Begin() *DBModel {
 	return db
 }
 
+// BeginConn starts a new database transaction on a dedicated connection, so that RawConn gives the
+// driver connection of the transaction (e.g. to COPY rows in the transaction). Other transactions
+// should use Begin.
+//
+// Parameters:
+//   - ctx (context.Context): The context reserving the connection and starting the transaction.
+//
+// Returns:
+//   - *DBModel: The DBModel instance with an active transaction.
+//   - error: An error if no connection can be reserved or the transaction cannot be started.
+//
+// Example:
+//
+//	dbInstance, err := mb.Instance().BeginConn(ctx)
+//	if err != nil {
+//	    return err
+//	}
+//	if _, err = psql.CopyFrom(ctx, dbInstance, users); err != nil {
+//	    _ = dbInstance.Rollback()
+//	    return err
+//	}
+//	err = dbInstance.Commit()
+func (db *DBModel) BeginConn(ctx context.Context) (*DBModel, error) {
+	conn, tx, err := dbInstanceConnTx(ctx)
+	if err != nil {
+		return db, err
+	}
+
+	db.conn, db.tx = conn, tx
+
+	return db, nil
+}
+
 // Rollback rolls back the current database transaction.
 //
 // Returns:
@@ -962,6 +1313,9 @@ func (db *DBModel) Begin() *DBModel {
 func (db *DBModel) Rollback() error {
 	// Check if there’s an active transaction.
 	if db.tx != nil {
+		// Return the connection of the transaction to the pool.
+		defer db.closeConn()
+
 		// Attempt to roll back the transaction and return the result.
 		return db.tx.Rollback()
 	}
@@ -977,6 +1331,9 @@ func (db *DBModel) Rollback() error {
 func (db *DBModel) Commit() error {
 	// Check if there’s an active transaction.
 	if db.tx != nil {
+		// Return the connection of the transaction to the pool.
+		defer db.closeConn()
+
 		// Attempt to commit the transaction and return the result.
 		return db.tx.Commit()
 	}
@@ -985,6 +1342,55 @@ func (db *DBModel) Commit() error {
 	return nil
 }
 
+// closeConn closes the connection of the transaction, which returns it to the pool.
+func (db *DBModel) closeConn() {
+	if db.conn != nil {
+		_ = db.conn.Close()
+		db.conn = nil
+	}
+}
+
+// RawConn calls fn with the driver connection (e.g. *stdlib.Conn of pgx) to use driver-specific
+// features such as the COPY protocol. Inside a transaction started by BeginConn, the connection of the
+// transaction is given, so that the operations of fn are part of the transaction. The connection of a
+// transaction started by Begin is not reachable, and an error is returned. Otherwise, a connection of
+// the pool is reserved during fn.
+//
+// Parameters:
+//   - ctx (context.Context): The context reserving the connection.
+//   - fn (func(driverConn any) error): The function using the driver connection. The connection
+//     must not be used after fn returns.
+//
+// Returns:
+//   - error: The error of fn, or an error if no connection can be reserved.
+//
+// Example:
+//
+//	err := mb.Instance().RawConn(ctx, func(driverConn any) error {
+//	    conn := driverConn.(*stdlib.Conn).Conn() // *pgx.Conn
+//	    _, err := conn.CopyFrom(ctx, pgx.Identifier{"users"}, columns, source)
+//	    return err
+//	})
+func (db *DBModel) RawConn(ctx context.Context, fn func(driverConn any) error) error {
+	if db.tx != nil && db.conn != nil {
+		return db.conn.Raw(fn)
+	}
+
+	if db.tx != nil {
+		return errors.New("Invalid transaction :: start the transaction with BeginConn to use its connection")
+	}
+
+	conn, err := dbInstance.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	return conn.Raw(fn)
+}
+
 // ToQueryBuilder converts the DBModel to a QueryBuilder for use as a subquery.
 // This method builds a QueryBuilder from the current DBModel's state without executing it.
 //
@@ -1013,83 +1419,18 @@ func (db *DBModel) ToQueryBuilder() (*qb.QueryBuilder, error) {
 		return nil, errors.New("Model must be set before converting to QueryBuilder")
 	}
 
-	// Get the type of model and create a table representation
-	var table *Table
-	modelType := reflect.TypeOf(db.model)
-	modelValue := reflect.ValueOf(db.model)
-
-	// Handle pointer to struct
-	if modelType.Kind() == reflect.Ptr {
-		modelType = modelType.Elem()
-		modelValue = modelValue.Elem()
-	}
-
-	table = processModel(modelType, modelValue, NewTable())
-
-	// Define the columns to query
-	var selectColumns []any
-	if len(db.selectStatement.Columns) > 0 {
-		selectColumns = db.selectStatement.Columns
-	} else {
-		selectColumns = []any{"*"}
-	}
-
-	// Create query builder
-	queryBuilder := qb.QueryInstance().
-		Select(selectColumns...).
-		From(table.Name)
-
-	// Build WHERE condition from the condition list
-	for _, condition := range db.whereStatement.Conditions {
-		// Sub-conditions
-		switch {
-		case len(condition.Group) > 0:
-			// Append conditions from a group to query builder
-			queryBuilder.WhereGroup(func(whereBuilder qb.WhereBuilder) *qb.WhereBuilder {
-				whereBuilder.WhereCondition(condition.Group...)
-				return &whereBuilder
-			})
-		case condition.AndOr == And:
-			// Add Where AND condition
-			queryBuilder.Where(condition.Field, condition.Opt, condition.Value)
-		case condition.AndOr == Or:
-			// Add Where OR condition
-			queryBuilder.WhereOr(condition.Field, condition.Opt, condition.Value)
-		}
-	}
-
-	// Build WHERE condition from model's data in the table
-	table.whereFromModel(queryBuilder)
-
-	// Build JOIN clause
-	for _, joinItem := range db.joinStatement.Items {
-		queryBuilder.Join(joinItem.Join, joinItem.Table, joinItem.Condition)
+	// Set operations can't be represented by a QueryBuilder
+	if len(db.unionStatement.Items) > 0 {
+		return nil, errors.New("Union subqueries are not supported yet")
 	}
 
-	// Build GROUP BY clause
-	if len(db.groupByStatement.Items) > 0 {
-		queryBuilder.GroupBy(db.groupByStatement.Items...)
-	}
-
-	// Build HAVING clause
-	for _, condition := range db.havingStatement.Conditions {
-		queryBuilder.Having(condition.Field, condition.Opt, condition.Value)
-	}
-
-	// Build LIMIT clause
-	if db.limitStatement.Limit > 0 {
-		queryBuilder.Limit(db.limitStatement.Limit, db.limitStatement.Offset)
-	}
-
-	// Build FETCH clause
-	if db.fetchStatement.Fetch > 0 {
-		queryBuilder.Fetch(db.fetchStatement.Offset, db.fetchStatement.Fetch)
+	queryBuilder, _, err := db.baseQuery(db.selectColumns()...)
+	if err != nil {
+		return nil, err
 	}
 
-	// Build ORDER BY clause
-	for _, orderItem := range db.orderByStatement.Items {
-		queryBuilder.OrderBy(orderItem.Field, orderItem.Direction)
-	}
+	// Build LIMIT, FETCH and ORDER BY clauses
+	db.paginateQuery(queryBuilder)
 
 	return queryBuilder, nil
 }
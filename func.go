@@ -1,10 +1,12 @@
 package db
 
 import (
+	"database/sql"
 	"encoding/json"
 	"github.com/gflydev/core/errors"
 	"reflect"
 	"strconv"
+	"time"
 )
 
 // setValue dynamically assigns a value to a struct field using reflection.
@@ -175,6 +177,58 @@ func setValue(model any, key string, data any) (err error) {
 	return
 }
 
+// setTimeValue assigns a timestamp to a struct field of a time type using reflection.
+// Unlike setValue, it supports time.Time, *time.Time and types implementing sql.Scanner
+// such as sql.NullTime, and it can clear the field.
+//
+// Parameters:
+//   - model (any): A pointer to the struct where the value will be set.
+//   - key (string): The exact field name in the struct.
+//   - t (*time.Time): The timestamp to assign. Nil clears the field (zero value).
+//
+// Returns:
+//   - error: An error if the field does not exist, is not settable or has an unsupported type.
+func setTimeValue(model any, key string, t *time.Time) error {
+	value := reflect.ValueOf(model)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return errors.New("Invalid data :: model not *Struct type")
+	}
+
+	// Retrieve the field by name
+	field := value.Elem().FieldByName(key)
+	if !field.IsValid() || !field.CanSet() {
+		return errors.New("Invalid field %s", key)
+	}
+
+	switch {
+	case t == nil:
+		field.Set(reflect.Zero(field.Type()))
+	case field.Type() == reflect.TypeOf(time.Time{}):
+		field.Set(reflect.ValueOf(*t))
+	case field.Type() == reflect.TypeOf(&time.Time{}):
+		field.Set(reflect.ValueOf(t))
+	case field.Addr().Type().Implements(reflect.TypeOf((*sql.Scanner)(nil)).Elem()):
+		return field.Addr().Interface().(sql.Scanner).Scan(*t)
+	default:
+		return errors.New("Unknown time type %s", key)
+	}
+
+	return nil
+}
+
+// isStructPointer reports whether the model is a pointer to a struct, so that its fields can be set.
+//
+// Parameters:
+//   - model (any): The model to check.
+//
+// Returns:
+//   - bool: True if the model is a non-nil pointer to a struct.
+func isStructPointer(model any) bool {
+	value := reflect.ValueOf(model)
+
+	return value.Kind() == reflect.Ptr && !value.IsNil() && value.Elem().Kind() == reflect.Struct
+}
+
 // toStr converts various data types to their string representation for database operations.
 // This utility function provides consistent string conversion for different Go types,
 // ensuring proper formatting for database queries and type conversions. It handles
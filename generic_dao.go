@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"github.com/gflydev/core/errors"
 	"github.com/gflydev/core/log"
@@ -192,19 +193,32 @@ func GetModelWhereEq[T any](field string, value any) (*T, error) {
 //   - error: An error object if an error occurs during the retrieval process.
 //     Returns nil if the query succeeds. Logs unexpected errors.
 func GetModel[T any](conditions ...Condition) (*T, error) {
+	return GetModelWithScopes[T](WhereScope(conditions...))
+}
+
+// GetModelWithScopes retrieves the first record of type T from the database
+// that matches the provided scopes.
+//
+// Generic Type:
+//   - T: The type of the model.
+//
+// Parameters:
+//   - scopes (...Scope): Variadic list of scopes building the query.
+//
+// Returns:
+//   - *T: A pointer to the retrieved model of type T, or nil if no matching record is found.
+//   - error: An error object if an error occurs during the retrieval process.
+//     Returns nil if the query succeeds. Logs unexpected errors.
+func GetModelWithScopes[T any](scopes ...Scope) (*T, error) {
 	var builder = Instance()
 	var err error
 	var m T
 
 	// Try/catch block
 	try.Perform(func() {
-		for _, condition := range conditions {
-			conditionConvert := condition.ToQBCondition()
-			builder.Where(conditionConvert.Field, conditionConvert.Opt, conditionConvert.Value)
-		}
-
-		// Get first record then assign to `m` WHERE field = value
+		builder.Scopes(scopes...)
 
+		// Get first record then assign to `m`
 		if e := builder.First(&m); e != nil {
 			try.Throw(e)
 		}
@@ -241,6 +255,27 @@ func GetModel[T any](conditions ...Condition) (*T, error) {
 //   - int: The total number of records that match the conditions.
 //   - error: An error object if an error occurs during the retrieval process.
 func FindModels[T any](page, limit int, sortField string, sortDir OrderByDir, conditions ...Condition) ([]T, int, error) {
+	return FindModelsWithScopes[T](page, limit, sortField, sortDir, WhereScope(conditions...))
+}
+
+// FindModelsWithScopes retrieves a paginated list of records of type T from the database
+// that match the provided scopes.
+//
+// Generic Type:
+//   - T: The type of the model.
+//
+// Parameters:
+//   - page (int): The current page number (1-based). Defaults to 0 if not provided.
+//   - limit (int): The number of records to retrieve per page.
+//   - sortField (string): The field name to sort the results by.
+//   - sortDir (qb.OrderByDir): The sorting direction (qb.Asc for ascending, qb.Desc for descending).
+//   - scopes (...Scope): Variadic list of scopes building the query.
+//
+// Returns:
+//   - []T: A slice of records of type T.
+//   - int: The total number of records that match the scopes.
+//   - error: An error object if an error occurs during the retrieval process.
+func FindModelsWithScopes[T any](page, limit int, sortField string, sortDir OrderByDir, scopes ...Scope) ([]T, int, error) {
 	var builder = Instance()
 	var items []T
 	var total int
@@ -252,10 +287,7 @@ func FindModels[T any](page, limit int, sortField string, sortDir OrderByDir, co
 	}
 
 	try.Perform(func() {
-		for _, condition := range conditions {
-			conditionConvert := condition.ToQBCondition()
-			builder.Where(conditionConvert.Field, conditionConvert.Opt, conditionConvert.Value)
-		}
+		builder.Scopes(scopes...)
 
 		builder.OrderBy(sortField, sortDir)
 
@@ -330,6 +362,7 @@ func CreateModel[T any](m *T) error {
 //
 // Returns:
 //   - error: An error object if an error occurs during the update process.
+//     errors.ItemNotFound if the record doesn't exist.
 func UpdateModel[T any](m *T) error {
 	var err error
 	db := Instance()
@@ -338,8 +371,11 @@ func UpdateModel[T any](m *T) error {
 		// Begin transaction
 		db.Begin()
 
-		// Attempt to update the record
-		if e := db.Update(m); e != nil {
+		// Attempt to update the record, which must exist
+		if e := db.Strict().Update(m); e != nil {
+			if errors.Is(e, ErrNoRowsAffected) {
+				e = errors.ItemNotFound
+			}
 			try.Throw(e)
 		}
 
@@ -368,6 +404,7 @@ func UpdateModel[T any](m *T) error {
 //
 // Returns:
 //   - error: An error object if an error occurs during the deletion process.
+//     errors.ItemNotFound if the record doesn't exist.
 func DeleteModel[T any](m *T) error {
 	var err error
 	db := Instance()
@@ -376,8 +413,11 @@ func DeleteModel[T any](m *T) error {
 		// Begin transaction
 		db.Begin()
 
-		// Attempt to delete the record
-		if e := db.Delete(m); e != nil {
+		// Attempt to delete the record, which must exist
+		if e := db.Strict().Delete(m); e != nil {
+			if errors.Is(e, ErrNoRowsAffected) {
+				e = errors.ItemNotFound
+			}
 			try.Throw(e)
 		}
 
@@ -393,3 +433,186 @@ func DeleteModel[T any](m *T) error {
 
 	return err
 }
+
+// ====================================================================
+//                          Aggregate methods
+// ====================================================================
+
+// CountModels counts the records of type T matching the provided conditions.
+//
+// Generic Type:
+//   - T: The type of the model.
+//
+// Parameters:
+//   - conditions (...Condition): Variadic list of conditions to filter the query.
+//
+// Returns:
+//   - int64: The number of matching records.
+//   - error: An error object if an error occurs during the query.
+func CountModels[T any](conditions ...Condition) (int64, error) {
+	var m T
+
+	total, err := Instance().Model(&m).Scopes(WhereScope(conditions...)).Count(context.Background())
+	if err != nil {
+		log.Error(err)
+	}
+
+	return total, err
+}
+
+// SumModels sums a column of the records of type T matching the provided conditions.
+//
+// Generic Type:
+//   - T: The type of the model.
+//
+// Parameters:
+//   - field (string): The column to sum.
+//   - conditions (...Condition): Variadic list of conditions to filter the query.
+//
+// Returns:
+//   - float64: The sum of the column. 0 if no record matches.
+//   - error: An error object if an error occurs during the query.
+func SumModels[T any](field string, conditions ...Condition) (float64, error) {
+	var m T
+
+	value, err := Instance().Model(&m).Scopes(WhereScope(conditions...)).Sum(context.Background(), field)
+	if err != nil {
+		log.Error(err)
+	}
+
+	return value, err
+}
+
+// AvgModels averages a column of the records of type T matching the provided conditions.
+//
+// Generic Type:
+//   - T: The type of the model.
+//
+// Parameters:
+//   - field (string): The column to average.
+//   - conditions (...Condition): Variadic list of conditions to filter the query.
+//
+// Returns:
+//   - float64: The average of the column. 0 if no record matches.
+//   - error: An error object if an error occurs during the query.
+func AvgModels[T any](field string, conditions ...Condition) (float64, error) {
+	var m T
+
+	value, err := Instance().Model(&m).Scopes(WhereScope(conditions...)).Avg(context.Background(), field)
+	if err != nil {
+		log.Error(err)
+	}
+
+	return value, err
+}
+
+// MinModels returns the minimum value of a column of the records of type T matching the provided conditions.
+//
+// Generic Type:
+//   - T: The type of the model.
+//   - V: The type of the value. Use a nullable type (e.g. sql.NullTime) when no record may match.
+//
+// Parameters:
+//   - field (string): The column.
+//   - conditions (...Condition): Variadic list of conditions to filter the query.
+//
+// Returns:
+//   - V: The minimum value.
+//   - error: An error object if an error occurs during the query.
+func MinModels[T, V any](field string, conditions ...Condition) (V, error) {
+	var m T
+	var value V
+
+	err := Instance().Model(&m).Scopes(WhereScope(conditions...)).Min(context.Background(), field, &value)
+	if err != nil {
+		log.Error(err)
+	}
+
+	return value, err
+}
+
+// MaxModels returns the maximum value of a column of the records of type T matching the provided conditions.
+//
+// Generic Type:
+//   - T: The type of the model.
+//   - V: The type of the value. Use a nullable type (e.g. sql.NullTime) when no record may match.
+//
+// Parameters:
+//   - field (string): The column.
+//   - conditions (...Condition): Variadic list of conditions to filter the query.
+//
+// Returns:
+//   - V: The maximum value.
+//   - error: An error object if an error occurs during the query.
+func MaxModels[T, V any](field string, conditions ...Condition) (V, error) {
+	var m T
+	var value V
+
+	err := Instance().Model(&m).Scopes(WhereScope(conditions...)).Max(context.Background(), field, &value)
+	if err != nil {
+		log.Error(err)
+	}
+
+	return value, err
+}
+
+// ExistsModel reports whether a record of type T matches the provided conditions.
+//
+// Generic Type:
+//   - T: The type of the model.
+//
+// Parameters:
+//   - conditions (...Condition): Variadic list of conditions to filter the query.
+//
+// Returns:
+//   - bool: True if a record matches.
+//   - error: An error object if an error occurs during the query.
+func ExistsModel[T any](conditions ...Condition) (bool, error) {
+	var m T
+
+	found, err := Instance().Model(&m).Scopes(WhereScope(conditions...)).Exists(context.Background())
+	if err != nil {
+		log.Error(err)
+	}
+
+	return found, err
+}
+
+// ====================================================================
+//                          Projection methods
+// ====================================================================
+
+// PluckOf retrieves the values of a single column of the records of type T
+// matching the provided conditions.
+//
+// Generic Type:
+//   - T: The type of the model.
+//   - V: The type of the column values.
+//
+// Parameters:
+//   - field (string): The column to retrieve.
+//   - conditions (...Condition): Variadic list of conditions to filter the query.
+//
+// Returns:
+//   - []V: The values of the column. An empty slice if no record matches.
+//   - error: An error object if an error occurs during the query.
+//
+// Example:
+//
+//	ids, err := mb.PluckOf[models.User, int]("id", mb.Condition{Field: "status", Opt: mb.Eq, Value: "active"})
+func PluckOf[T, V any](field string, conditions ...Condition) ([]V, error) {
+	var m T
+	var values []V
+
+	err := Instance().Model(&m).Scopes(WhereScope(conditions...)).Pluck(context.Background(), field, &values)
+	if err != nil {
+		log.Error(err)
+	}
+
+	// For case empty list => return an empty []V
+	if values == nil || err != nil {
+		values = []V{}
+	}
+
+	return values, err
+}
@@ -0,0 +1,172 @@
+package db
+
+import (
+	"fmt"
+	qb "github.com/jivegroup/fluentsql"
+	"strings"
+)
+
+// ====================================================================
+//                            Row locking
+// ====================================================================
+
+// LockStrength represents the strength of a row locking clause.
+type LockStrength int
+
+// Lock strength constants
+const (
+	LockNone   LockStrength = iota // No locking clause
+	LockUpdate                     // FOR UPDATE (exclusive lock)
+	LockShare                      // FOR SHARE (shared lock)
+)
+
+// LockOption represents the behavior when the selected rows are already locked.
+type LockOption int
+
+// Lock option constants
+const (
+	LockWait       LockOption = iota // Wait until the rows are released (default)
+	LockNoWait                       // NOWAIT: fail immediately
+	LockSkipLocked                   // SKIP LOCKED: ignore the locked rows
+)
+
+// Lock represents the row locking clause of a SELECT statement.
+//
+// Fields:
+//   - Strength (LockStrength): The lock strength. LockNone disables the clause.
+//   - Tables ([]string): The tables to lock (OF clause). Empty locks all tables of the query.
+//   - Option (LockOption): The behavior when the rows are already locked.
+//
+// Database-specific implementations:
+//   - PostgreSQL: FOR UPDATE | FOR SHARE [OF table, ...] [NOWAIT | SKIP LOCKED]
+//   - MySQL 8: FOR UPDATE | FOR SHARE [OF table, ...] [NOWAIT | SKIP LOCKED]
+//   - SQLite: not supported, no clause is generated
+type Lock struct {
+	Strength LockStrength
+	Tables   []string
+	Option   LockOption
+}
+
+// String generates the SQL row locking clause for the current dialect.
+//
+// Returns:
+//   - string: The locking clause. Returns an empty string if no lock is required.
+func (l *Lock) String() string {
+	if l.Strength == LockNone || qb.IsDialect(qb.SQLite) {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	switch l.Strength {
+	case LockUpdate:
+		sb.WriteString("FOR UPDATE")
+	case LockShare:
+		sb.WriteString("FOR SHARE")
+	}
+
+	if len(l.Tables) > 0 {
+		sb.WriteString(fmt.Sprintf(" OF %s", strings.Join(l.Tables, ", ")))
+	}
+
+	switch l.Option {
+	case LockNoWait:
+		sb.WriteString(" NOWAIT")
+	case LockSkipLocked:
+		sb.WriteString(" SKIP LOCKED")
+	}
+
+	return sb.String()
+}
+
+// ====================================================================
+//                        DB Model row locking
+// ====================================================================
+
+// LockForUpdate locks the selected rows exclusively (FOR UPDATE) until the transaction ends.
+// The lock is applied by First, Last, Get and Find and only lasts as long as the current
+// transaction, so it should be used after Begin().
+//
+// Returns:
+//   - *DBModel: A reference to the DBModel instance for chaining.
+//
+// Example:
+//
+//	db := mb.Instance().Begin()
+//	defer db.Rollback()
+//
+//	var item Inventory
+//	err := db.Where("sku", mb.Eq, sku).LockForUpdate().First(&item)
+//	item.Reserved++
+//	err = db.Update(&item)
+//	err = db.Commit()
+func (db *DBModel) LockForUpdate() *DBModel {
+	db.lockStatement.Strength = LockUpdate
+
+	return db
+}
+
+// SharedLock locks the selected rows in shared mode (FOR SHARE) until the transaction ends.
+// Other transactions can read the rows but not modify them.
+//
+// Returns:
+//   - *DBModel: A reference to the DBModel instance for chaining.
+func (db *DBModel) SharedLock() *DBModel {
+	db.lockStatement.Strength = LockShare
+
+	return db
+}
+
+// SkipLocked skips the rows already locked by other transactions (SKIP LOCKED).
+// It requires LockForUpdate or SharedLock.
+//
+// Returns:
+//   - *DBModel: A reference to the DBModel instance for chaining.
+func (db *DBModel) SkipLocked() *DBModel {
+	db.lockStatement.Option = LockSkipLocked
+
+	return db
+}
+
+// NoWait fails immediately when the rows are already locked by other transactions (NOWAIT).
+// It requires LockForUpdate or SharedLock.
+//
+// Returns:
+//   - *DBModel: A reference to the DBModel instance for chaining.
+func (db *DBModel) NoWait() *DBModel {
+	db.lockStatement.Option = LockNoWait
+
+	return db
+}
+
+// LockOf restricts the locking clause to the given tables (OF table, ...).
+// Useful with JOIN queries where only some tables need to be locked.
+//
+// Parameters:
+//   - tables (...string): The tables (or aliases) to lock.
+//
+// Returns:
+//   - *DBModel: A reference to the DBModel instance for chaining.
+func (db *DBModel) LockOf(tables ...string) *DBModel {
+	db.lockStatement.Tables = append(db.lockStatement.Tables, tables...)
+
+	return db
+}
+
+// selectSql generates the SQL of a SELECT query with the row locking clause.
+//
+// Parameters:
+//   - q (*qb.QueryBuilder): The query builder comprising the SQL query and arguments.
+//
+// Returns:
+//   - string: The SQL query string.
+//   - []any: The arguments of the query.
+func (db *DBModel) selectSql(q *qb.QueryBuilder) (string, []any) {
+	sqlStr, args, _ := q.Sql()
+
+	if lockSql := db.lockStatement.String(); lockSql != "" {
+		sqlStr += " " + lockSql
+	}
+
+	return sqlStr, args
+}
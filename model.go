@@ -10,6 +10,7 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"time"
 )
 
 // ====================================================================
@@ -25,6 +26,11 @@ const (
 	CASCADE   = "cascade" // Cascade rules for DELETE and UPDATE
 	RELATION  = "rel"     // Relation to another table
 	NAME      = "name"    // Column name in the database
+
+	SOFT_DELETE      = "soft_delete"    // Column storing the deletion time of soft deleted rows
+	AUTO_CREATE_TIME = "autoCreateTime" // Column set to the current time on creation
+	AUTO_UPDATE_TIME = "autoUpdateTime" // Column set to the current time on creation and update
+	VERSION          = "version"        // Integer column used for optimistic locking
 )
 
 // MetaData represents metadata string information
@@ -115,6 +121,8 @@ type MetaData string
 type Table struct {
 	Name          string         // Database table name derived from struct name (snake_case)
 	PrimarySerial *Column        // Primary serial column for unique identification and operations
+	SoftDelete    *Column        // Column storing the deletion time of soft deleted rows
+	Version       *Column        // Integer column used for optimistic locking
 	Primaries     []Column       // Primary key columns for unique identification and operations
 	Columns       []Column       // Complete column definitions with metadata and constraints
 	Values        map[string]any // Current column values indexed by column name for operations
@@ -133,6 +141,12 @@ type Column struct {
 	Relation string // Relation to another table
 	IsZero   bool   // Indicates if the column value is the zero value for its type
 	HasValue bool   // Indicates if the column has a valid (non-zero) value
+
+	SoftDelete     bool          // Indicates if the column stores the deletion time of soft deleted rows
+	AutoCreateTime bool          // Indicates if the column is set to the current time on creation
+	AutoUpdateTime bool          // Indicates if the column is set to the current time on creation and update
+	TimePrecision  time.Duration // Precision of automatic timestamps (zero for the default precision)
+	Version        bool          // Indicates if the column is used for optimistic locking
 }
 
 // isNotData determines if the column is not valid data for the table
@@ -188,6 +202,34 @@ func ModelData(model any) (*Table, error) {
 	return nil, errors.New("Input param should be a struct")
 }
 
+// CreateData converts a Go struct into the Table representation of a record to insert.
+// Unlike ModelData, it assigns the automatic timestamps and the initial version of optimistic locking.
+//
+// Parameters:
+//
+//	model (any): The struct or pointer to struct to be inserted. When a pointer is given, its fields are set as well.
+//
+// Returns:
+//
+//	*Table - The table structure representing the record to insert
+//	error  - An error if the input is not a struct or pointer to struct
+func CreateData(model any) (*Table, error) {
+	tbl, err := ModelData(model)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set automatic timestamps
+	if err = tbl.setAutoTimes(model, true); err != nil {
+		return nil, err
+	}
+
+	// Start the version of optimistic locking
+	tbl.setInitialVersion(model)
+
+	return tbl, nil
+}
+
 // ====================================================================
 //                         Process methods
 // ====================================================================
@@ -228,6 +270,11 @@ func processModel(typ reflect.Type, value reflect.Value, tbl *Table) *Table {
 		// Check if the field is a serial column by analyzing its `type` attribute.
 		isSerialColumn := isSerial(attr[TYPE])
 
+		// Skip the embedded dirty tracking state.
+		if typeField.Type == dirtyTrackerType {
+			continue
+		}
+
 		// Process special MetaData type fields for table-specific settings.
 		if typeField.Type == reflect.TypeOf(MetaData("")) {
 			if slice, tableOk := attr[TABLE]; tableOk && len(slice) > 0 {
@@ -297,6 +344,22 @@ func processModel(typ reflect.Type, value reflect.Value, tbl *Table) *Table {
 		col.Primary = isPrimaryColumn
 		col.Serial = isSerialColumn
 
+		// Check if the column stores the deletion time of soft deleted rows.
+		_, col.SoftDelete = attr[SOFT_DELETE]
+
+		// Check if the column is used for optimistic locking.
+		_, col.Version = attr[VERSION]
+
+		// Process automatic timestamps (e.g. `autoCreateTime` or `autoUpdateTime:milli`).
+		if slice, ok := attr[AUTO_CREATE_TIME]; ok {
+			col.AutoCreateTime = true
+			col.TimePrecision = parseTimePrecision(slice)
+		}
+		if slice, ok := attr[AUTO_UPDATE_TIME]; ok {
+			col.AutoUpdateTime = true
+			col.TimePrecision = parseTimePrecision(slice)
+		}
+
 		// Mark the column as a primary key if applicable.
 		if isPrimaryColumn {
 			tbl.Primaries = append(tbl.Primaries, col)
@@ -305,6 +368,14 @@ func processModel(typ reflect.Type, value reflect.Value, tbl *Table) *Table {
 		if isSerialColumn && isPrimaryColumn {
 			tbl.PrimarySerial = &col
 		}
+		// Mark the column as the soft delete column if applicable.
+		if col.SoftDelete {
+			tbl.SoftDelete = &col
+		}
+		// Mark the column as the version column if applicable.
+		if col.Version {
+			tbl.Version = &col
+		}
 
 		// Add the column to the list of table columns.
 		tbl.Columns = append(tbl.Columns, col)
@@ -318,6 +389,7 @@ func processModel(typ reflect.Type, value reflect.Value, tbl *Table) *Table {
 //
 // Parameters:
 //   - tags (string): A semicolon-separated string of attributes in the format 'key:value1,value2,...'.
+//     Flag attributes without values (e.g. 'soft_delete') are mapped to an empty slice.
 //
 // Returns:
 //   - map[string][]string: A map where keys are attribute names (e.g., "type") and values are slices
@@ -339,8 +411,20 @@ func readTags(tags string) map[string][]string {
 
 	// Iterate through each attribute string.
 	for i := 0; i < len(attributes); i++ {
+		// Skip empty attributes (e.g. trailing semicolon).
+		if attributes[i] == "" {
+			continue
+		}
+
 		// Split each attribute string into the attribute name (key) and values.
 		pre := strings.SplitN(attributes[i], ":", 2)
+
+		// Flag attribute without values.
+		if len(pre) == 1 {
+			vals[pre[0]] = []string{}
+			continue
+		}
+
 		// Assign the attribute values (split by commas) to the corresponding key in the map.
 		vals[pre[0]] = strings.Split(pre[1], ",")
 	}
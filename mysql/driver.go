@@ -32,6 +32,8 @@ func New() *MySQL {
 type MySQL struct{}
 
 // Load establishes a connection to the MySQL database.
+// Set DB_CLIENT_FOUND_ROWS to true so that UPDATE reports the matched rows instead of the changed rows,
+// which Strict mode requires to accept updates writing the same values.
 //
 // Returns:
 //
@@ -49,6 +51,11 @@ func (d *MySQL) Load() (*sqlx.DB, error) {
 		utils.Getenv("DB_NAME", "gfly"),       // Database name
 	)
 
+	// Report the matched rows of UPDATE statements instead of the changed rows (opt-in).
+	if utils.Getenv("DB_CLIENT_FOUND_ROWS", false) {
+		connURL += "?clientFoundRows=true"
+	}
+
 	// Attempt to connect to the database using the constructed connection URL.
 	return db.Connect(connURL, "mysql")
 }
@@ -0,0 +1,195 @@
+package db
+
+import (
+	"context"
+	"github.com/gflydev/core/errors"
+	"sync/atomic"
+)
+
+// ====================================================================
+//                            Pagination
+// ====================================================================
+
+// DefaultMaxPerPage is the default maximum number of items per page.
+const DefaultMaxPerPage = 100
+
+// maxPerPage caps the number of items per page of Paginate.
+var maxPerPage atomic.Int64
+
+func init() {
+	maxPerPage.Store(DefaultMaxPerPage)
+}
+
+// SetMaxPerPage sets the maximum number of items per page of Paginate.
+// Larger perPage values are capped to it.
+//
+// Parameters:
+//   - n (int): The maximum number of items per page. Values < 1 restore DefaultMaxPerPage.
+func SetMaxPerPage(n int) {
+	if n < 1 {
+		n = DefaultMaxPerPage
+	}
+
+	maxPerPage.Store(int64(n))
+}
+
+// Pagination represents the metadata of a page.
+//
+// Fields:
+//   - Total (int): The total number of items matching the query.
+//   - Page (int): The current page number (1-based).
+//   - PerPage (int): The number of items per page, after capping.
+//   - LastPage (int): The number of the last page (1 when there is no item).
+//   - From (int): The position (1-based) of the first item of the page. 0 for an empty page.
+//   - To (int): The position (1-based) of the last item of the page. 0 for an empty page.
+type Pagination struct {
+	Total    int `json:"total"`
+	Page     int `json:"page"`
+	PerPage  int `json:"perPage"`
+	LastPage int `json:"lastPage"`
+	From     int `json:"from"`
+	To       int `json:"to"`
+}
+
+// HasNext reports whether a page follows the current page.
+func (p Pagination) HasNext() bool {
+	return p.Page < p.LastPage
+}
+
+// HasPrev reports whether a page precedes the current page.
+func (p Pagination) HasPrev() bool {
+	return p.Page > 1
+}
+
+// Page represents a page of items with its metadata.
+// It serializes to JSON as {"items": [...], "total": 0, "page": 1, "perPage": 10, "lastPage": 1, "from": 0, "to": 0}.
+//
+// Fields:
+//   - Items ([]T): The items of the page. Never nil.
+//   - Pagination: The metadata of the page.
+type Page[T any] struct {
+	Items []T `json:"items"`
+	Pagination
+}
+
+// Paginate retrieves a page of rows and returns the metadata of the page.
+// ORDER BY and WHERE clauses are applied; LIMIT and FETCH are replaced by the page.
+// The total follows the count strategy set by WithCount (e.g. CountWindow or CountEstimate).
+//
+// Parameters:
+//   - ctx (context.Context): The context of the queries.
+//   - page (int): The page number (1-based).
+//   - perPage (int): The number of items per page. It is capped by SetMaxPerPage (default 100).
+//   - items (any): A pointer to the slice where the rows of the page will be stored.
+//
+// Returns:
+//   - Pagination: The metadata of the page.
+//   - error: An error if page or perPage is lower than 1, or if the query fails.
+//
+// Example:
+//
+//	var users []User
+//	pagination, err := mb.Instance().Where("status", mb.Eq, "active").
+//	    OrderBy("id", mb.Desc).
+//	    Paginate(ctx, 2, 20, &users)
+func (db *DBModel) Paginate(ctx context.Context, page, perPage int, items any) (pagination Pagination, err error) {
+	// Reset fluent model builder, also when an error stops the query
+	defer db.reset()
+
+	if db.raw.sqlStr != "" {
+		return pagination, errors.New("Raw SQL queries are not supported by Paginate")
+	}
+
+	if db.countStrategy == CountNone {
+		return pagination, errors.New("WithoutCount is not supported by Paginate, use CursorPaginate instead")
+	}
+
+	if page < 1 {
+		return pagination, errors.New("Invalid page %d :: page must be greater than 0", page)
+	}
+
+	if perPage < 1 {
+		return pagination, errors.New("Invalid perPage %d :: perPage must be greater than 0", perPage)
+	}
+
+	if limit := int(maxPerPage.Load()); perPage > limit {
+		perPage = limit
+	}
+
+	// Replace pagination clauses by the page
+	db.fetchStatement.Fetch = 0
+	db.Limit(perPage, (page-1)*perPage)
+
+	var total int
+	if total, err = db.find(ctx, items); err != nil {
+		return
+	}
+
+	return newPagination(total, page, perPage), nil
+}
+
+// PaginateOf retrieves a page of rows of type T using the query of the DBModel.
+//
+// Generic Type:
+//   - T: The type of the model.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the queries.
+//   - db (*DBModel): The query to paginate.
+//   - page (int): The page number (1-based).
+//   - perPage (int): The number of items per page. It is capped by SetMaxPerPage (default 100).
+//
+// Returns:
+//   - Page[T]: The page of items with its metadata.
+//   - error: An error if page or perPage is lower than 1, or if the query fails.
+//
+// Example:
+//
+//	page, err := mb.PaginateOf[User](ctx, mb.Instance().Where("status", mb.Eq, "active"), 1, 20)
+//	data, _ := json.Marshal(page)
+func PaginateOf[T any](ctx context.Context, db *DBModel, page, perPage int) (Page[T], error) {
+	var items []T
+
+	pagination, err := db.Paginate(ctx, page, perPage, &items)
+
+	// For case empty list => return an empty []T
+	if items == nil || err != nil {
+		items = []T{}
+	}
+
+	return Page[T]{
+		Items:      items,
+		Pagination: pagination,
+	}, err
+}
+
+// newPagination computes the metadata of a page.
+//
+// Parameters:
+//   - total (int): The total number of items.
+//   - page (int): The page number (1-based).
+//   - perPage (int): The number of items per page.
+//
+// Returns:
+//   - Pagination: The metadata of the page.
+func newPagination(total, page, perPage int) Pagination {
+	pagination := Pagination{
+		Total:    total,
+		Page:     page,
+		PerPage:  perPage,
+		LastPage: (total + perPage - 1) / perPage,
+	}
+
+	if pagination.LastPage < 1 {
+		pagination.LastPage = 1
+	}
+
+	// Positions of the items of the page, when the page is not beyond the last item
+	offset := (page - 1) * perPage
+	if offset < total {
+		pagination.From = offset + 1
+		pagination.To = min(offset+perPage, total)
+	}
+
+	return pagination
+}
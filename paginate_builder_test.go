@@ -0,0 +1,179 @@
+package db
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	qb "github.com/jivegroup/fluentsql"
+)
+
+// useMaxPerPage sets the maximum number of items per page. The maximum is restored when the test ends.
+func useMaxPerPage(t *testing.T, n int) {
+	t.Helper()
+
+	previous := int(maxPerPage.Load())
+	t.Cleanup(func() {
+		SetMaxPerPage(previous)
+	})
+
+	SetMaxPerPage(n)
+}
+
+func TestPaginate(t *testing.T) {
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect),
+		fakeResult{columns: []string{"id"}, rows: [][]driver.Value{{int64(3)}, {int64(2)}}},
+		fakeResult{columns: []string{"total"}, rows: [][]driver.Value{{int64(5)}}},
+	)
+
+	var posts []unionPost
+	pagination, err := Instance().Where("id", Greater, 0).OrderBy("id", Desc).Limit(50, 7).
+		Paginate(context.Background(), 2, 2, &posts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPagination := Pagination{Total: 5, Page: 2, PerPage: 2, LastPage: 3, From: 3, To: 4}
+	if pagination != expectedPagination {
+		t.Errorf("pagination = %+v, expected %+v", pagination, expectedPagination)
+	}
+
+	if !pagination.HasNext() || !pagination.HasPrev() {
+		t.Errorf("HasNext, HasPrev = %v, %v, expected true, true", pagination.HasNext(), pagination.HasPrev())
+	}
+
+	// The page replaces the LIMIT of the query
+	expected := []string{
+		"SELECT * FROM posts WHERE id > $1 ORDER BY id DESC LIMIT $2 OFFSET $3",
+		"SELECT COUNT(*) AS total FROM (SELECT * FROM posts WHERE id > $1 ORDER BY id DESC) _result_out_",
+	}
+	if !reflect.DeepEqual(fake.statements, expected) {
+		t.Errorf("statements = %q, expected %q", fake.statements, expected)
+	}
+
+	expectedArgs := []driver.Value{int64(0), int64(2), int64(2)}
+	if !reflect.DeepEqual(fake.args[0], expectedArgs) {
+		t.Errorf("args = %v, expected %v", fake.args[0], expectedArgs)
+	}
+}
+
+func TestPaginateMaxPerPage(t *testing.T) {
+	useMaxPerPage(t, 10)
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect),
+		fakeResult{columns: []string{"id"}},
+		fakeResult{columns: []string{"total"}, rows: [][]driver.Value{{int64(0)}}},
+	)
+
+	var posts []unionPost
+	pagination, err := Instance().Paginate(context.Background(), 3, 50, &posts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pagination.PerPage != 10 {
+		t.Errorf("PerPage = %d, expected 10", pagination.PerPage)
+	}
+
+	expectedArgs := []driver.Value{int64(10), int64(20)}
+	if len(fake.args) == 0 || !reflect.DeepEqual(fake.args[0], expectedArgs) {
+		t.Errorf("args = %v, expected %v", fake.args, expectedArgs)
+	}
+
+	// Values < 1 restore the default maximum
+	SetMaxPerPage(0)
+	if n := maxPerPage.Load(); n != DefaultMaxPerPage {
+		t.Errorf("maxPerPage = %d, expected %d", n, DefaultMaxPerPage)
+	}
+}
+
+func TestPaginateInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		page    int
+		perPage int
+	}{
+		{name: "page 0", page: 0, perPage: 10},
+		{name: "negative page", page: -1, perPage: 10},
+		{name: "perPage 0", page: 1, perPage: 0},
+		{name: "negative perPage", page: 1, perPage: -5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeDB(t, new(qb.PostgreSQLDialect))
+
+			var posts []unionPost
+			if _, err := Instance().Paginate(context.Background(), tt.page, tt.perPage, &posts); err == nil {
+				t.Error("Paginate() expected an error")
+			}
+
+			if len(fake.statements) != 0 {
+				t.Errorf("statements = %q, expected none", fake.statements)
+			}
+		})
+	}
+}
+
+func TestPaginateOf(t *testing.T) {
+	useFakeDB(t, new(qb.PostgreSQLDialect),
+		fakeResult{columns: []string{"id"}},
+		fakeResult{columns: []string{"total"}, rows: [][]driver.Value{{int64(0)}}},
+	)
+
+	page, err := PaginateOf[unionPost](context.Background(), Instance(), 1, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(page)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"items":[],"total":0,"page":1,"perPage":20,"lastPage":1,"from":0,"to":0}`
+	if string(data) != expected {
+		t.Errorf("json = %s, expected %s", data, expected)
+	}
+}
+
+func TestNewPagination(t *testing.T) {
+	tests := []struct {
+		name     string
+		total    int
+		page     int
+		perPage  int
+		expected Pagination
+	}{
+		{
+			name:     "no item",
+			total:    0,
+			page:     1,
+			perPage:  10,
+			expected: Pagination{Total: 0, Page: 1, PerPage: 10, LastPage: 1},
+		},
+		{
+			name:     "last page partially filled",
+			total:    25,
+			page:     3,
+			perPage:  10,
+			expected: Pagination{Total: 25, Page: 3, PerPage: 10, LastPage: 3, From: 21, To: 25},
+		},
+		{
+			name:     "page beyond the last item",
+			total:    25,
+			page:     4,
+			perPage:  10,
+			expected: Pagination{Total: 25, Page: 4, PerPage: 10, LastPage: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := newPagination(tt.total, tt.page, tt.perPage); result != tt.expected {
+				t.Errorf("newPagination() = %+v, expected %+v", result, tt.expected)
+			}
+		})
+	}
+}
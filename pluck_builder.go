@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"github.com/gflydev/core/errors"
+	"reflect"
+)
+
+// ====================================================================
+//                         Column projections
+// ====================================================================
+
+// Pluck scans the values of a single column into a slice.
+// WHERE, JOIN, GROUP BY, HAVING, ORDER BY, LIMIT and FETCH clauses are applied.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the query.
+//   - field (any): The column or expression to select (e.g. "email" or Expr("LOWER(email)")).
+//   - dest (any): A pointer to a slice receiving the values (e.g. *[]string, *[]int).
+//
+// Returns:
+//   - error: An error object if any issues occur during the query; nil otherwise.
+//
+// Example:
+//
+//	var emails []string
+//	err := mb.Instance().Model(&User{}).Where("status", mb.Eq, "active").Pluck(ctx, "email", &emails)
+//	// Executes: SELECT email FROM users WHERE status = $1
+func (db *DBModel) Pluck(ctx context.Context, field any, dest any) (err error) {
+	defer db.reset()
+
+	typ := reflect.TypeOf(dest)
+	if typ == nil || !(typ.Kind() == reflect.Ptr && typ.Elem().Kind() == reflect.Slice) {
+		return errors.New("Invalid data :: dest not *Slice type")
+	}
+
+	queryBuilder, _, err := db.baseQuery(db.expressionField(field))
+	if err != nil {
+		return
+	}
+
+	db.paginateQuery(queryBuilder)
+
+	sqlStr, args := db.selectSql(queryBuilder)
+
+	return db.queryRawContext(ctx, sqlStr, args, dest)
+}
+
+// PluckMap scans the values of two columns into a map: the first column gives the keys and
+// the second one the values. Later rows overwrite earlier rows having the same key.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the query.
+//   - keyField (any): The column or expression giving the keys.
+//   - valueField (any): The column or expression giving the values.
+//   - dest (any): A pointer to a map receiving the pairs (e.g. *map[int]string).
+//     A nil map is allocated.
+//
+// Returns:
+//   - error: An error object if any issues occur during the query; nil otherwise.
+//
+// Example:
+//
+//	var names map[int]string
+//	err := mb.Instance().Model(&User{}).PluckMap(ctx, "id", "fullname", &names)
+//	// Executes: SELECT id, fullname FROM users
+func (db *DBModel) PluckMap(ctx context.Context, keyField, valueField any, dest any) (err error) {
+	defer db.reset()
+
+	typ := reflect.TypeOf(dest)
+	if typ == nil || !(typ.Kind() == reflect.Ptr && typ.Elem().Kind() == reflect.Map) {
+		return errors.New("Invalid data :: dest not *Map type")
+	}
+
+	queryBuilder, _, err := db.baseQuery(db.expressionField(keyField), db.expressionField(valueField))
+	if err != nil {
+		return
+	}
+
+	db.paginateQuery(queryBuilder)
+
+	sqlStr, args := db.selectSql(queryBuilder)
+
+	rows, err := db.rowsRaw(ctx, sqlStr, args)
+	if err != nil {
+		return
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	// Allocate the map if needed
+	mapValue := reflect.ValueOf(dest).Elem()
+	if mapValue.IsNil() {
+		mapValue.Set(reflect.MakeMap(mapValue.Type()))
+	}
+
+	keyType := mapValue.Type().Key()
+	valueType := mapValue.Type().Elem()
+
+	for rows.Next() {
+		key := reflect.New(keyType)
+		value := reflect.New(valueType)
+
+		if err = rows.Scan(key.Interface(), value.Interface()); err != nil {
+			return
+		}
+
+		mapValue.SetMapIndex(key.Elem(), value.Elem())
+	}
+
+	return rows.Err()
+}
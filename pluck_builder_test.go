@@ -0,0 +1,96 @@
+package db
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+
+	qb "github.com/jivegroup/fluentsql"
+)
+
+func TestPluck(t *testing.T) {
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect), fakeResult{
+		columns: []string{"title"},
+		rows:    [][]driver.Value{{"a"}, {"b"}},
+	})
+
+	var titles []string
+	err := Instance().Model(&unionPost{}).
+		Where("id", Greater, 1).
+		OrderBy("id", Asc).
+		Limit(2, 0).
+		Pluck(context.Background(), Expr("COALESCE(title, ?)", "untitled"), &titles)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(titles, []string{"a", "b"}) {
+		t.Errorf("titles = %v, expected [a b]", titles)
+	}
+
+	expected := "SELECT COALESCE(title, $1) FROM posts WHERE id > $2 ORDER BY id ASC LIMIT $3 OFFSET $4"
+	if len(fake.statements) != 1 || fake.statements[0] != expected {
+		t.Errorf("statements = %q, expected %q", fake.statements, expected)
+	}
+}
+
+func TestPluckMap(t *testing.T) {
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect), fakeResult{
+		columns: []string{"id", "title"},
+		rows:    [][]driver.Value{{int64(1), "a"}, {int64(2), "b"}, {int64(1), "c"}},
+	})
+
+	var titles map[int]string
+	if err := Instance().Model(&unionPost{}).PluckMap(context.Background(), "id", "title", &titles); err != nil {
+		t.Fatal(err)
+	}
+
+	// Later rows overwrite earlier rows having the same key
+	if !reflect.DeepEqual(titles, map[int]string{1: "c", 2: "b"}) {
+		t.Errorf("titles = %v, expected map[1:c 2:b]", titles)
+	}
+
+	expected := "SELECT id, title FROM posts"
+	if len(fake.statements) != 1 || fake.statements[0] != expected {
+		t.Errorf("statements = %q, expected %q", fake.statements, expected)
+	}
+}
+
+func TestPluckOf(t *testing.T) {
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect), fakeResult{columns: []string{"id"}})
+
+	ids, err := PluckOf[unionPost, int]("id", Condition{Field: "title", Opt: Eq, Value: "go"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// No row gives an empty slice
+	if ids == nil || len(ids) != 0 {
+		t.Errorf("ids = %#v, expected an empty slice", ids)
+	}
+
+	expected := "SELECT id FROM posts WHERE title = $1"
+	if len(fake.statements) != 1 || fake.statements[0] != expected {
+		t.Errorf("statements = %q, expected %q", fake.statements, expected)
+	}
+}
+
+func TestPluckInvalidDest(t *testing.T) {
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect))
+	ctx := context.Background()
+
+	var titles []string
+	if err := Instance().Model(&unionPost{}).Pluck(ctx, "title", titles); err == nil {
+		t.Error("Pluck() of a slice expected an error")
+	}
+
+	var pairs map[int]string
+	if err := Instance().Model(&unionPost{}).PluckMap(ctx, "id", "title", pairs); err == nil {
+		t.Error("PluckMap() of a map expected an error")
+	}
+
+	if len(fake.statements) != 0 {
+		t.Errorf("statements = %q, expected none", fake.statements)
+	}
+}
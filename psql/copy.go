@@ -0,0 +1,231 @@
+package psql
+
+import (
+	"context"
+	"iter"
+	"reflect"
+	"strings"
+
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/db"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// ====================================================================
+//                        PostgreSQL COPY loader
+// ====================================================================
+
+// CopyFrom inserts the models with the COPY protocol of PostgreSQL, which is much faster than
+// INSERT statements to load a large number of rows. Automatic timestamps and the initial version
+// of optimistic locking are assigned like Create does; serial columns are left to the database
+// and are not back-filled. Unlike Create, which skips zero fields, every other column is copied
+// with the value of its field: zero values are written, and the column defaults don't apply.
+// Use pointer or sql.Null* fields to write NULL.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the COPY operation.
+//   - dbm (*db.DBModel): The model builder. When it is in a transaction started by BeginConn, the rows
+//     are copied in the transaction. When it is nil, a pooled connection is used.
+//   - rows ([]T): The models to insert. T is a struct or a pointer to struct.
+//
+// Returns:
+//   - int64: The number of copied rows.
+//   - error: An error if the models are invalid or the COPY operation fails.
+//
+// Example:
+//
+//	users := []models.User{{Email: "john@gfly.dev"}, {Email: "jane@gfly.dev"}}
+//	count, err := psql.CopyFrom(ctx, nil, users)
+func CopyFrom[T any](ctx context.Context, dbm *db.DBModel, rows []T) (int64, error) {
+	table, columns, err := copyColumns[T]()
+	if err != nil {
+		return 0, err
+	}
+
+	return copyFrom(ctx, dbm, table, columns, sliceSource(rows, columns))
+}
+
+// CopyFromSeq inserts the models of an iterator with the COPY protocol of PostgreSQL. The rows
+// are streamed to the database while they are produced, so they don't need to fit in memory.
+// Like CopyFrom, zero values are written instead of the column defaults.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the COPY operation.
+//   - dbm (*db.DBModel): The model builder. When it is in a transaction started by BeginConn, the rows
+//     are copied in the transaction. When it is nil, a pooled connection is used.
+//   - rows (iter.Seq[T]): The models to insert. T is a struct or a pointer to struct.
+//
+// Returns:
+//   - int64: The number of copied rows.
+//   - error: An error if the models are invalid or the COPY operation fails.
+//
+// Example:
+//
+//	count, err := psql.CopyFromSeq(ctx, nil, func(yield func(models.User) bool) {
+//	    for scanner.Scan() {
+//	        if !yield(models.User{Email: scanner.Text()}) {
+//	            return
+//	        }
+//	    }
+//	})
+func CopyFromSeq[T any](ctx context.Context, dbm *db.DBModel, rows iter.Seq[T]) (int64, error) {
+	table, columns, err := copyColumns[T]()
+	if err != nil {
+		return 0, err
+	}
+
+	source, stop := seqSource(rows, columns)
+	defer stop()
+
+	return copyFrom(ctx, dbm, table, columns, source)
+}
+
+// sliceSource gets the rows to copy from the models of a slice.
+//
+// Parameters:
+//   - rows ([]T): The models. T is a struct or a pointer to struct.
+//   - columns ([]db.Column): The copied columns.
+//
+// Returns:
+//   - pgx.CopyFromSource: The rows to copy.
+func sliceSource[T any](rows []T, columns []db.Column) pgx.CopyFromSource {
+	index := 0
+
+	return pgx.CopyFromFunc(func() ([]any, error) {
+		if index >= len(rows) {
+			return nil, nil
+		}
+		index++
+
+		// Address the element, so that its automatic fields are set as well
+		return copyValues(&rows[index-1], columns)
+	})
+}
+
+// seqSource gets the rows to copy from the models of an iterator.
+//
+// Parameters:
+//   - rows (iter.Seq[T]): The models. T is a struct or a pointer to struct.
+//   - columns ([]db.Column): The copied columns.
+//
+// Returns:
+//   - pgx.CopyFromSource: The rows to copy.
+//   - func(): The function stopping the iterator. It must be called when the copy ends.
+func seqSource[T any](rows iter.Seq[T], columns []db.Column) (pgx.CopyFromSource, func()) {
+	next, stop := iter.Pull(rows)
+
+	return pgx.CopyFromFunc(func() ([]any, error) {
+		row, ok := next()
+		if !ok {
+			return nil, nil
+		}
+
+		return copyValues(&row, columns)
+	}), stop
+}
+
+// copyFrom runs the COPY operation on the pgx connection of the model builder.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the COPY operation.
+//   - dbm (*db.DBModel): The model builder, or nil for a pooled connection.
+//   - table (string): The table name, optionally qualified by the schema.
+//   - columns ([]db.Column): The copied columns.
+//   - source (pgx.CopyFromSource): The rows to copy.
+//
+// Returns:
+//   - int64: The number of copied rows.
+//   - error: An error if the COPY operation fails.
+func copyFrom(ctx context.Context, dbm *db.DBModel, table string, columns []db.Column, source pgx.CopyFromSource) (int64, error) {
+	if dbm == nil {
+		dbm = db.Instance()
+	}
+
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.Name
+	}
+
+	var count int64
+	err := dbm.RawConn(ctx, func(driverConn any) error {
+		conn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return errors.New("COPY requires the pgx driver of PostgreSQL")
+		}
+
+		var err error
+		count, err = conn.Conn().CopyFrom(ctx, pgx.Identifier(strings.Split(table, ".")), names, source)
+
+		return err
+	})
+
+	return count, err
+}
+
+// copyColumns gets the table and the columns copied for the models of type T.
+// Serial columns, relations and references are not copied.
+//
+// Returns:
+//   - string: The table name.
+//   - []db.Column: The copied columns.
+//   - error: An error if T is not a struct or a pointer to struct.
+func copyColumns[T any]() (string, []db.Column, error) {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return "", nil, errors.New("Invalid data :: Model must be a Struct or a *Struct")
+	}
+
+	table, err := db.ModelData(reflect.New(typ).Interface())
+	if err != nil {
+		return "", nil, err
+	}
+
+	var columns []db.Column
+	for _, column := range table.Columns {
+		if column.Serial || column.Relation != "" || column.Ref != "" {
+			continue
+		}
+		columns = append(columns, column)
+	}
+
+	if len(columns) == 0 {
+		return "", nil, errors.New("Invalid data :: Model has no column to copy")
+	}
+
+	return table.Name, columns, nil
+}
+
+// copyValues gets the values of a model to copy, in the order of the columns.
+//
+// Parameters:
+//   - row (*T): The model. T is a struct or a pointer to struct.
+//   - columns ([]db.Column): The copied columns.
+//
+// Returns:
+//   - []any: The values of the row.
+//   - error: An error if the model is invalid.
+func copyValues[T any](row *T, columns []db.Column) ([]any, error) {
+	var model any = row
+	if value := reflect.ValueOf(*row); value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil, errors.New("Invalid data :: Model must not be nil")
+		}
+		model = *row
+	}
+
+	table, err := db.CreateData(model)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]any, len(columns))
+	for i, column := range columns {
+		values[i] = table.Values[column.Name]
+	}
+
+	return values, nil
+}
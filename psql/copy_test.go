@@ -0,0 +1,252 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gflydev/db"
+	"github.com/jackc/pgx/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+type copyUser struct {
+	MetaData  db.MetaData `db:"-" model:"table:users"`
+	ID        int         `db:"id" model:"name:id; type:serial,primary"`
+	Email     string      `db:"email" model:"name:email"`
+	Status    string      `db:"status" model:"name:status"`
+	Version   int         `db:"version" model:"name:version; version"`
+	CreatedAt time.Time   `db:"created_at" model:"name:created_at; autoCreateTime"`
+}
+
+// useClock sets the time of the automatic timestamps. The clock is restored when the test ends.
+func useClock(t *testing.T, now time.Time) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		db.SetClock(nil)
+	})
+
+	db.SetClock(func() time.Time {
+		return now
+	})
+}
+
+// readSource reads every row of a COPY source.
+func readSource(t *testing.T, source pgx.CopyFromSource) [][]any {
+	t.Helper()
+
+	var rows [][]any
+	for source.Next() {
+		values, err := source.Values()
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows = append(rows, values)
+	}
+
+	if err := source.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	return rows
+}
+
+func TestCopyColumns(t *testing.T) {
+	table, columns, err := copyColumns[*copyUser]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if table != "users" {
+		t.Errorf("table = %q, expected users", table)
+	}
+
+	// Serial columns are generated by the database
+	var names []string
+	for _, column := range columns {
+		names = append(names, column.Name)
+	}
+
+	expected := []string{"email", "status", "version", "created_at"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("columns = %v, expected %v", names, expected)
+	}
+
+	if _, _, err = copyColumns[string](); err == nil {
+		t.Error("copyColumns() of a string expected an error")
+	}
+}
+
+func TestCopySources(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	useClock(t, now)
+
+	_, columns, err := copyColumns[copyUser]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Zero fields are written instead of the column defaults
+	expected := [][]any{
+		{"john@gfly.dev", "", 1, now},
+		{"jane@gfly.dev", "active", 1, now},
+	}
+
+	t.Run("CopyFrom slice", func(t *testing.T) {
+		users := []copyUser{{Email: "john@gfly.dev"}, {Email: "jane@gfly.dev", Status: "active"}}
+
+		rows := readSource(t, sliceSource(users, columns))
+		if !reflect.DeepEqual(rows, expected) {
+			t.Errorf("rows = %v, expected %v", rows, expected)
+		}
+
+		// The automatic fields are set on the elements
+		if !users[0].CreatedAt.Equal(now) || users[1].Version != 1 {
+			t.Errorf("users = %+v, expected the automatic fields to be set", users)
+		}
+	})
+
+	t.Run("CopyFrom slice of pointers", func(t *testing.T) {
+		users := []*copyUser{{Email: "john@gfly.dev"}, {Email: "jane@gfly.dev", Status: "active"}}
+
+		rows := readSource(t, sliceSource(users, columns))
+		if !reflect.DeepEqual(rows, expected) {
+			t.Errorf("rows = %v, expected %v", rows, expected)
+		}
+
+		if !users[1].CreatedAt.Equal(now) {
+			t.Errorf("CreatedAt = %v, expected %v", users[1].CreatedAt, now)
+		}
+	})
+
+	t.Run("CopyFromSeq", func(t *testing.T) {
+		users := []copyUser{{Email: "john@gfly.dev"}, {Email: "jane@gfly.dev", Status: "active"}}
+
+		source, stop := seqSource(slices.Values(users), columns)
+		defer stop()
+
+		rows := readSource(t, source)
+		if !reflect.DeepEqual(rows, expected) {
+			t.Errorf("rows = %v, expected %v", rows, expected)
+		}
+	})
+
+	t.Run("nil element", func(t *testing.T) {
+		source := sliceSource([]*copyUser{nil}, columns)
+		if source.Next() {
+			t.Error("Next() = true, expected false for a nil model")
+		}
+
+		if source.Err() == nil {
+			t.Error("Err() of a nil model expected an error")
+		}
+	})
+}
+
+func TestCopySeqStop(t *testing.T) {
+	_, columns, err := copyColumns[copyUser]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Stopping the copy stops the iterator
+	var produced int
+	source, stop := seqSource(func(yield func(copyUser) bool) {
+		for {
+			produced++
+			if !yield(copyUser{Email: "john@gfly.dev"}) {
+				return
+			}
+		}
+	}, columns)
+
+	source.Next()
+	stop()
+
+	if source.Next() {
+		t.Error("Next() = true after stop, expected false")
+	}
+
+	if produced != 1 {
+		t.Errorf("produced = %d, expected 1", produced)
+	}
+}
+
+// Test driver whose connections are not pgx connections.
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return fakeConn{}, nil }
+func (fakeConn) Commit() error                       { return nil }
+func (fakeConn) Rollback() error                     { return nil }
+
+type fakeLoader struct{}
+
+func (fakeLoader) Load() (*sqlx.DB, error) { return sqlx.Open("psqlfake", "") }
+
+var fakeOnce sync.Once
+
+func TestCopyFromRequiresPgx(t *testing.T) {
+	fakeOnce.Do(func() {
+		sql.Register("psqlfake", fakeDriver{})
+	})
+
+	db.Register(fakeLoader{})
+	db.Load()
+
+	ctx := context.Background()
+	users := []copyUser{{Email: "john@gfly.dev"}}
+
+	if _, err := CopyFrom(ctx, nil, users); err == nil {
+		t.Error("CopyFrom() expected an error for a connection of another driver")
+	}
+
+	if _, err := CopyFromSeq(ctx, nil, slices.Values(users)); err == nil {
+		t.Error("CopyFromSeq() expected an error for a connection of another driver")
+	}
+
+	// The connection of a transaction started by Begin is not reachable
+	dbInstance := db.Instance().Begin()
+	defer func() {
+		_ = dbInstance.Rollback()
+	}()
+
+	if _, err := CopyFrom(ctx, dbInstance, users); err == nil {
+		t.Error("CopyFrom() expected an error in a transaction not started by BeginConn")
+	}
+
+	// The transaction started by BeginConn gives its connection
+	connInstance, err := db.Instance().BeginConn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = connInstance.Rollback()
+	}()
+
+	var driverConn any
+	if err = connInstance.RawConn(ctx, func(conn any) error {
+		driverConn = conn
+
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := driverConn.(fakeConn); !ok {
+		t.Errorf("RawConn() gave %T, expected the connection of the transaction", driverConn)
+	}
+}
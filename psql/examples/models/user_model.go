@@ -43,11 +43,11 @@ type User struct {
 	Phone        string         `db:"phone" model:"name:phone"`
 	Token        sql.NullString `db:"token" model:"name:token"`
 	Status       string         `db:"status" model:"name:status"`
-	CreatedAt    time.Time      `db:"created_at" model:"name:created_at"`
+	CreatedAt    time.Time      `db:"created_at" model:"name:created_at; autoCreateTime"`
 	Avatar       sql.NullString `db:"avatar" model:"name:avatar"`
-	UpdatedAt    time.Time      `db:"updated_at" model:"name:updated_at"`
+	UpdatedAt    time.Time      `db:"updated_at" model:"name:updated_at; autoUpdateTime"`
 	VerifiedAt   sql.NullTime   `db:"verified_at" model:"name:verified_at"`
 	BlockedAt    sql.NullTime   `db:"blocked_at" model:"name:blocked_at"`
-	DeletedAt    sql.NullTime   `db:"deleted_at" model:"name:deleted_at"`
+	DeletedAt    sql.NullTime   `db:"deleted_at" model:"name:deleted_at; soft_delete"`
 	LastAccessAt sql.NullTime   `db:"last_access_at" model:"name:last_access_at"`
 }
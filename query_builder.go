@@ -1,11 +1,10 @@
 package db
 
 import (
-	"crypto/rand"
+	"context"
 	"fmt"
 	"github.com/gflydev/core/errors"
 	qb "github.com/jivegroup/fluentsql"
-	"math/big"
 	"reflect"
 )
 
@@ -24,7 +23,7 @@ const (
 	// GetLast retrieves the last record ordered by primary key in descending order.
 	GetLast
 
-	// TakeOne retrieves a random record.
+	// TakeOne retrieves a random record, sampled with the strategy set by WithSample.
 	TakeOne
 )
 
@@ -64,6 +63,16 @@ func (db *DBModel) Last(model any) (err error) {
 // Returns:
 //   - err (error): An error object if any issues occur during the retrieval process; nil otherwise.
 func (db *DBModel) Get(model any, getType GetOne) (err error) {
+	// Reset fluent model builder, also when an error stops the query
+	defer db.reset()
+
+	// Keep the loaded values of models tracking their changes
+	defer func() {
+		if err == nil {
+			snapshotModels(model)
+		}
+	}()
+
 	// Query raw SQL
 	if db.raw.sqlStr != "" {
 		// Data persistence
@@ -73,9 +82,6 @@ func (db *DBModel) Get(model any, getType GetOne) (err error) {
 			err = dbInstance.Get(model, db.raw.sqlStr, db.raw.args...)
 		}
 
-		// Reset fluent model builder
-		db.reset()
-
 		return
 	}
 
@@ -87,6 +93,11 @@ func (db *DBModel) Get(model any, getType GetOne) (err error) {
 		return
 	}
 
+	// Random record
+	if getType == TakeOne {
+		return db.takeOne(context.Background(), model)
+	}
+
 	var table *Table
 
 	// Create a table object from a model
@@ -94,81 +105,15 @@ func (db *DBModel) Get(model any, getType GetOne) (err error) {
 	if err != nil {
 		return
 	}
+	db.applyTable(table)
 
-	// Define the columns to be queried
-	var selectColumns []any
-	if len(db.selectStatement.Columns) > 0 {
-		selectColumns = db.selectStatement.Columns
-	} else {
-		selectColumns = []any{"*"}
-	}
-
-	// Create a query builder with initial SELECT, FROM, and LIMIT clauses
-	queryBuilder := qb.QueryInstance().
-		Select(selectColumns...).
-		From(table.Name).
+	// Create a query builder for one row. The non-zero fields of the model, including the
+	// primary keys, are conditions.
+	queryBuilder := db.selectQuery(table, model, db.selectColumns()...).
 		Limit(1, 0)
 
-	// Build WHERE condition using primary columns
-	for _, primaryColumn := range table.Primaries {
-		if !primaryColumn.IsZero {
-			primaryKey := primaryColumn.Name
-			primaryVal := table.Values[primaryKey]
-
-			// Build WHERE condition with a specific primary value
-			wherePrimaryCondition := qb.Condition{
-				Field: primaryKey,
-				Opt:   Eq,
-				Value: primaryVal,
-				AndOr: And,
-			}
-			queryBuilder.WhereCondition(wherePrimaryCondition)
-		}
-	}
-
-	// Build WHERE condition from a condition list
-	for _, condition := range db.whereStatement.Conditions {
-		// Handle grouped or individual conditions
-		switch {
-		case len(condition.Group) > 0:
-			queryBuilder.WhereGroup(func(whereBuilder qb.WhereBuilder) *qb.WhereBuilder {
-				whereBuilder.WhereCondition(condition.Group...)
-				return &whereBuilder
-			})
-		case condition.AndOr == And:
-			queryBuilder.Where(condition.Field, condition.Opt, condition.Value)
-		case condition.AndOr == Or:
-			queryBuilder.WhereOr(condition.Field, condition.Opt, condition.Value)
-		}
-	}
-
-	// Build WHERE condition from the model's data
-	table.whereFromModel(queryBuilder)
-
-	// Build JOIN clause
-	for _, joinItem := range db.joinStatement.Items {
-		queryBuilder.Join(joinItem.Join, joinItem.Table, joinItem.Condition)
-	}
-
-	// Build GROUP BY clause
-	if len(db.groupByStatement.Items) > 0 {
-		queryBuilder.GroupBy(db.groupByStatement.Items...)
-	}
-
-	// Build HAVING clause
-	for _, condition := range db.havingStatement.Conditions {
-		queryBuilder.Having(condition.Field, condition.Opt, condition.Value)
-	}
-
-	// Build LIMIT clause
-	if db.limitStatement.Limit > 0 {
-		queryBuilder.Limit(db.limitStatement.Limit, db.limitStatement.Offset)
-	}
-
-	// Build FETCH clause
-	if db.fetchStatement.Fetch > 0 {
-		queryBuilder.Fetch(db.fetchStatement.Offset, db.fetchStatement.Fetch)
-	}
+	// Build LIMIT and FETCH clauses
+	db.limitQuery(queryBuilder)
 
 	// Build ORDER BY clause
 	orderByField := ""
@@ -186,24 +131,12 @@ func (db *DBModel) Get(model any, getType GetOne) (err error) {
 		orderByDir = Desc
 	case getType == GetFirst && orderByField != "":
 		orderByDir = Asc
-	case getType == TakeOne: // Random order by field and direction
-		n, _ := rand.Int(rand.Reader, big.NewInt(int64(len(table.Columns)-1)))
-		orderByField = table.Columns[n.Int64()].Name
-
-		n, _ = rand.Int(rand.Reader, big.NewInt(10))
-		if n.Int64()%2 == 1 {
-			orderByDir = Asc
-		} else {
-			orderByDir = Desc
-		}
 	}
 	queryBuilder.OrderBy(orderByField, orderByDir)
 
-	// Data processing using the constructed query
-	err = db.get(queryBuilder, model)
-
-	// Reset fluent model builder
-	db.reset()
+	// Data processing using the constructed query and row locking clause
+	sqlStr, args := db.selectSql(queryBuilder)
+	err = db.getRaw(sqlStr, args, model)
 
 	return
 }
@@ -221,29 +154,45 @@ func (db *DBModel) Get(model any, getType GetOne) (err error) {
 //   - total (int): The total number of rows matching the query criteria.
 //   - err (error): An error object if any issues occur during the retrieval process; nil otherwise.
 func (db *DBModel) Find(model any) (total int, err error) {
+	return db.find(context.Background(), model)
+}
+
+// find searches for multiple rows in the database based on query criteria with a context.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the queries (cancellation, deadline).
+//   - model (any): A pointer to the slice where the retrieved rows will be stored.
+//
+// Returns:
+//   - total (int): The total number of rows matching the query criteria.
+//   - err (error): An error object if any issues occur during the retrieval process; nil otherwise.
+func (db *DBModel) find(ctx context.Context, model any) (total int, err error) {
+	// Reset fluent model builder, also when an error stops the query
+	defer db.reset()
+
+	// Keep the loaded values of models tracking their changes
+	defer func() {
+		if err == nil {
+			snapshotModels(model)
+		}
+	}()
+
 	// Query raw SQL
 	if db.raw.sqlStr != "" {
 		// Data persistence
-		if db.tx != nil {
-			err = db.tx.Select(model, db.raw.sqlStr, db.raw.args...)
-		} else {
-			err = dbInstance.Select(model, db.raw.sqlStr, db.raw.args...)
-		}
-
-		if err != nil {
+		if err = db.queryRawContext(ctx, db.raw.sqlStr, db.raw.args, model); err != nil {
 			return
 		}
 
 		// Query COUNT
-		sqlCount := fmt.Sprintf("SELECT COUNT(*) AS total FROM (%s) _result_out_", db.raw.sqlStr)
+		if db.countStrategy != CountNone {
+			sqlCount := fmt.Sprintf("SELECT COUNT(*) AS total FROM (%s) _result_out_", db.raw.sqlStr)
 
-		if err = db.getRaw(sqlCount, db.raw.args, &total); err != nil {
-			return
+			if err = db.getRawContext(ctx, sqlCount, db.raw.args, &total); err != nil {
+				return
+			}
 		}
 
-		// Reset fluent model builder
-		db.reset()
-
 		return
 	}
 
@@ -257,39 +206,148 @@ func (db *DBModel) Find(model any) (total int, err error) {
 
 	var table *Table
 
-	// Get the type of model and create a table representation
-	typeElement := reflect.TypeOf(model).Elem().Elem()  // First Elem() for pointer, second Elem() for item
-	valueElement := reflect.ValueOf(typeElement).Elem() // Create empty value
-	table = processModel(typeElement, valueElement, NewTable())
+	if len(db.unionStatement.Items) > 0 && db.model != nil {
+		// The first query of set operations takes its table from the model
+		if table, err = ModelData(db.model); err != nil {
+			return
+		}
+	} else if isMapSlice(model) {
+		// Rows as maps take their table from Table (schemaless)
+		if db.table == "" {
+			err = errors.New("Table must be set to find rows as maps")
+
+			return
+		}
+
+		table = db.schemalessTable()
+	} else {
+		// Get the type of model and create a table representation
+		typeElement := reflect.TypeOf(model).Elem().Elem()  // First Elem() for pointer, second Elem() for item
+		valueElement := reflect.ValueOf(typeElement).Elem() // Create empty value
+		table = processModel(typeElement, valueElement, NewTable())
+	}
+
+	// Replace the table of the model by the one of Table
+	db.applyTable(table)
+
+	// Global scopes come from the model (the first query's model for set operations)
+	scopeModel := model
+	if len(db.unionStatement.Items) > 0 && db.model != nil {
+		scopeModel = db.model
+	}
 
 	// Define the columns to query
-	var selectColumns []any
-	if len(db.selectStatement.Columns) > 0 {
-		selectColumns = db.selectStatement.Columns
+	selectColumns := append([]any(nil), db.selectColumns()...)
+
+	// Count the rows in the same query (window functions can't be combined with set operations or row locking)
+	windowCount := db.countStrategy == CountWindow && len(db.unionStatement.Items) == 0 &&
+		db.lockStatement.Strength == LockNone && !isMapSlice(model)
+	if windowCount {
+		selectColumns = append(selectColumns, "COUNT(*) OVER() AS "+windowCountColumn)
+	}
+
+	// Create query builder
+	queryBuilder := db.selectQuery(table, scopeModel, selectColumns...)
+
+	// Combine with set operations. ORDER BY, LIMIT and FETCH apply to the combined result
+	if len(db.unionStatement.Items) > 0 {
+		total, err = db.findUnion(ctx, queryBuilder, model)
+
+		return
+	}
+
+	// Build LIMIT, FETCH and ORDER BY clauses
+	db.paginateQuery(queryBuilder)
+
+	// Execute query with row locking clause and populate model
+	sqlStr, args := db.selectSql(queryBuilder)
+	if windowCount {
+		err = db.queryWindow(ctx, sqlStr, args, model, &total)
 	} else {
-		selectColumns = []any{"*"}
+		err = db.queryRawContext(ctx, sqlStr, args, model)
+	}
+
+	if err != nil {
+		return
+	}
+
+	// Get the total number of rows with the count strategy
+	switch {
+	case db.countStrategy == CountNone:
+	case windowCount:
+		// No row in a page beyond the last row: the window gives no total
+		if total == 0 && (db.limitStatement.Offset > 0 || db.fetchStatement.Offset > 0) {
+			err = db.count(ctx, queryBuilder, &total)
+		}
+	case db.countStrategy == CountEstimate:
+		err = db.estimateCount(ctx, queryBuilder, table, &total)
+	default:
+		err = db.count(ctx, queryBuilder, &total)
+	}
+
+	return
+}
+
+// ====================================================================
+//                          Base SELECT query
+// ====================================================================
+
+// baseQuery builds a SELECT query on the model's table with the WHERE, JOIN, GROUP BY and
+// HAVING clauses of the DBModel. ORDER BY, LIMIT and FETCH clauses are not added.
+// Global scopes and the soft delete condition of the model are applied.
+//
+// Parameters:
+//   - columns (...any): The columns to select.
+//
+// Returns:
+//   - *qb.QueryBuilder: The query builder.
+//   - *Table: The table of the model.
+//   - error: An error if the model is not set or set operations are used.
+func (db *DBModel) baseQuery(columns ...any) (*qb.QueryBuilder, *Table, error) {
+	if len(db.unionStatement.Items) > 0 {
+		return nil, nil, errors.New("Union queries are not supported by this operator")
 	}
 
+	table, err := db.modelTable()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return db.selectQuery(table, db.model, columns...), table, nil
+}
+
+// selectQuery builds a SELECT query on a table with the WHERE, JOIN, GROUP BY and HAVING clauses
+// of the DBModel. The non-zero fields of the model data in the table are added as conditions.
+// Global scopes and the soft delete condition are applied first.
+//
+// Parameters:
+//   - table (*Table): The table of the query.
+//   - scopeModel (any): The model whose global scopes are applied (a model, or a pointer to a slice of models).
+//   - columns (...any): The columns to select.
+//
+// Returns:
+//   - *qb.QueryBuilder: The query builder.
+func (db *DBModel) selectQuery(table *Table, scopeModel any, columns ...any) *qb.QueryBuilder {
+	// Apply global scopes and soft delete condition of the model
+	db.applyGlobalScopes(scopeModel)
+	db.applySoftDelete(table)
+
 	// Create query builder
 	queryBuilder := qb.QueryInstance().
-		Select(selectColumns...).
+		Select(columns...).
 		From(table.Name)
 
 	// Build WHERE condition from the condition list
 	for _, condition := range db.whereStatement.Conditions {
-		// Sub-conditions
 		switch {
 		case len(condition.Group) > 0:
-			// Append conditions from a group to query builder
 			queryBuilder.WhereGroup(func(whereBuilder qb.WhereBuilder) *qb.WhereBuilder {
 				whereBuilder.WhereCondition(condition.Group...)
 				return &whereBuilder
 			})
 		case condition.AndOr == And:
-			// Add Where AND condition
 			queryBuilder.Where(condition.Field, condition.Opt, condition.Value)
 		case condition.AndOr == Or:
-			// Add Where OR condition
 			queryBuilder.WhereOr(condition.Field, condition.Opt, condition.Value)
 		}
 	}
@@ -312,33 +370,47 @@ func (db *DBModel) Find(model any) (total int, err error) {
 		queryBuilder.Having(condition.Field, condition.Opt, condition.Value)
 	}
 
-	// Build LIMIT clause
-	if db.limitStatement.Limit > 0 {
-		queryBuilder.Limit(db.limitStatement.Limit, db.limitStatement.Offset)
-	}
+	return queryBuilder
+}
 
-	// Build FETCH clause
-	if db.fetchStatement.Fetch > 0 {
-		queryBuilder.Fetch(db.fetchStatement.Offset, db.fetchStatement.Fetch)
+// selectColumns returns the columns given by Select, or all columns.
+//
+// Returns:
+//   - []any: The columns to select.
+func (db *DBModel) selectColumns() []any {
+	if len(db.selectStatement.Columns) > 0 {
+		return db.selectStatement.Columns
 	}
 
+	return []any{"*"}
+}
+
+// paginateQuery adds the ORDER BY, LIMIT and FETCH clauses of the DBModel to a query builder.
+//
+// Parameters:
+//   - queryBuilder (*qb.QueryBuilder): The query builder.
+func (db *DBModel) paginateQuery(queryBuilder *qb.QueryBuilder) {
+	// Build LIMIT and FETCH clauses
+	db.limitQuery(queryBuilder)
+
 	// Build ORDER BY clause
 	for _, orderItem := range db.orderByStatement.Items {
 		queryBuilder.OrderBy(orderItem.Field, orderItem.Direction)
 	}
+}
 
-	// Execute query and populate model
-	if err = db.query(queryBuilder, model); err != nil {
-		return
+// limitQuery adds the LIMIT and FETCH clauses of the DBModel to a query builder.
+//
+// Parameters:
+//   - queryBuilder (*qb.QueryBuilder): The query builder.
+func (db *DBModel) limitQuery(queryBuilder *qb.QueryBuilder) {
+	// Build LIMIT clause
+	if db.limitStatement.Limit > 0 {
+		queryBuilder.Limit(db.limitStatement.Limit, db.limitStatement.Offset)
 	}
 
-	// Execute count query to get the total number of rows
-	if err = db.count(queryBuilder, &total); err != nil {
-		return
+	// Build FETCH clause
+	if db.fetchStatement.Fetch > 0 {
+		queryBuilder.Fetch(db.fetchStatement.Offset, db.fetchStatement.Fetch)
 	}
-
-	// Reset fluent model builder
-	db.reset()
-
-	return
 }
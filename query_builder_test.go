@@ -0,0 +1,101 @@
+package db
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	qb "github.com/jivegroup/fluentsql"
+)
+
+type queryTenant struct {
+	MetaData  MetaData `db:"-" model:"table:tenants"`
+	ID        int      `db:"id" model:"name:id; type:serial,primary"`
+	Status    string   `db:"status" model:"name:status"`
+	DeletedAt *string  `db:"deleted_at" model:"name:deleted_at; soft_delete"`
+}
+
+func TestSelectQueries(t *testing.T) {
+	AddGlobalScope[queryTenant]("active", WhereScope(Condition{Field: "tenants.active", Opt: Eq, Value: true}))
+	t.Cleanup(func() {
+		RemoveGlobalScope[queryTenant]("active")
+	})
+
+	// The WHERE conditions of the query are kept together before the scope and the soft delete condition
+	const where = "WHERE (status = $1 OR status = $2) AND tenants.active = $3 AND tenants.deleted_at IS NULL"
+
+	query := func() *DBModel {
+		return Instance().Where("status", Eq, "a").WhereOr("status", Eq, "b")
+	}
+
+	tests := []struct {
+		name     string
+		run      func() (string, error)
+		expected string
+	}{
+		{
+			name: "Find",
+			run: func() (string, error) {
+				var tenants []queryTenant
+				_, err := query().WithCount(CountNone).Limit(10, 20).OrderBy("id", Desc).Find(&tenants)
+
+				return "", err
+			},
+			expected: "SELECT * FROM tenants " + where + " ORDER BY id DESC LIMIT $4 OFFSET $5",
+		},
+		{
+			name: "First",
+			run: func() (string, error) {
+				return "", query().First(&queryTenant{ID: 3})
+			},
+			expected: "SELECT * FROM tenants " + where + " AND id = $4 ORDER BY id ASC LIMIT $5 OFFSET $6",
+		},
+		{
+			name: "ToQueryBuilder",
+			run: func() (string, error) {
+				queryBuilder, err := query().Model(&queryTenant{}).Limit(10, 0).OrderBy("id", Asc).ToQueryBuilder()
+				if err != nil {
+					return "", err
+				}
+
+				sqlStr, _, _ := queryBuilder.Sql()
+
+				return sqlStr, nil
+			},
+			expected: "SELECT * FROM tenants " + where + " ORDER BY id ASC LIMIT $4 OFFSET $5",
+		},
+		{
+			name: "Rows",
+			run: func() (string, error) {
+				rows, err := query().Model(&queryTenant{}).WithTrashed().Rows(t.Context())
+				if err == nil {
+					_ = rows.Close()
+				}
+
+				return "", err
+			},
+			expected: "SELECT * FROM tenants WHERE (status = $1 OR status = $2) AND tenants.active = $3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeDB(t, new(qb.PostgreSQLDialect), fakeResult{
+				columns: []string{"id"},
+				rows:    [][]driver.Value{{int64(3)}},
+			})
+
+			sqlStr, err := tt.run()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if sqlStr == "" && len(fake.statements) > 0 {
+				sqlStr = fake.statements[0]
+			}
+
+			if sqlStr != tt.expected {
+				t.Errorf("SQL = %q, expected %q", sqlStr, tt.expected)
+			}
+		})
+	}
+}
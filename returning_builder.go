@@ -0,0 +1,269 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/gflydev/core/errors"
+	qb "github.com/jivegroup/fluentsql"
+	"github.com/jmoiron/sqlx"
+)
+
+// ====================================================================
+//                          RETURNING clause
+// ====================================================================
+
+// returningState represents the RETURNING options of UPDATE and DELETE statements.
+//
+// Fields:
+//   - columns ([]string): The returned columns. No RETURNING clause when it's empty.
+//   - dest (any): The *Struct or pointer to slice receiving the returned rows.
+type returningState struct {
+	columns []string
+	dest    any
+}
+
+// Returning scans the rows affected by Update or Delete back into their model, so that values computed by
+// the database (defaults, triggers, expressions) are read without another query.
+// Update and Delete of a *Struct receive the first affected row. Delete of a pointer to slice receives all
+// deleted rows of the WHERE conditions.
+// PostgreSQL and SQLite use a RETURNING clause. MySQL reads the rows in a transaction: before a deletion,
+// and after an update by their primary keys.
+//
+// Parameters:
+//   - columns (...string): The returned columns. Defaults to "*".
+//
+// Returns:
+//   - *DBModel: A reference to the DBModel instance for chaining.
+//
+// Examples:
+//
+//	// UPDATE users SET name = $1, updated_at = $2 WHERE id = $3 RETURNING *
+//	err := db.Returning("*").Update(&user)
+//
+//	// DELETE FROM sessions WHERE expired_at < $1 RETURNING *
+//	var sessions []Session
+//	err = db.Where("expired_at", mb.Lesser, time.Now()).Returning().Delete(&sessions)
+func (db *DBModel) Returning(columns ...string) *DBModel {
+	if len(columns) == 0 {
+		columns = []string{"*"}
+	}
+
+	db.returning.columns = columns
+
+	return db
+}
+
+// returns checks whether a column is returned into the model.
+//
+// Parameters:
+//   - column (string): The column name.
+//
+// Returns:
+//   - bool: true if the column is returned; false otherwise.
+func (db *DBModel) returns(column string) bool {
+	return slices.Contains(db.returning.columns, "*") || slices.Contains(db.returning.columns, column)
+}
+
+// execReturning executes an UPDATE or DELETE statement and scans the affected rows into the
+// destination of Returning. Without Returning, the statement is just executed.
+//
+// Parameters:
+//   - sqlStr (string): The UPDATE or DELETE statement.
+//   - args ([]any): Arguments for the query placeholders.
+//   - table (*Table): The table of the statement.
+//   - conditions ([]qb.Condition): The WHERE conditions of the statement.
+//   - removing (bool): Whether the statement removes the rows, so that they must be read before.
+//
+// Returns:
+//   - err (error): Error encountered during execution, if any.
+func (db *DBModel) execReturning(sqlStr string, args []any, table *Table, conditions []qb.Condition, removing bool) (err error) {
+	if len(db.returning.columns) == 0 {
+		return db.execRaw(sqlStr, args)
+	}
+
+	if qb.IsDialect(qb.MySQL) {
+		return db.execReturningMySQL(sqlStr, args, table, conditions, removing)
+	}
+
+	rows, err := db.rowsRaw(context.Background(), sqlStr+" RETURNING "+strings.Join(db.returning.columns, ", "), args)
+	if err != nil {
+		return
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	db.rowsAffected, err = scanReturning(rows, db.returning.dest)
+
+	return
+}
+
+// execReturningMySQL emulates the RETURNING clause on MySQL in a transaction. The rows to remove are read
+// before the statement. The updated rows are locked and read back by their primary keys after the statement.
+//
+// Parameters:
+//   - sqlStr (string): The UPDATE or DELETE statement.
+//   - args ([]any): Arguments for the query placeholders.
+//   - table (*Table): The table of the statement.
+//   - conditions ([]qb.Condition): The WHERE conditions of the statement.
+//   - removing (bool): Whether the statement removes the rows.
+//
+// Returns:
+//   - err (error): Error encountered during execution, if any.
+func (db *DBModel) execReturningMySQL(sqlStr string, args []any, table *Table, conditions []qb.Condition, removing bool) (err error) {
+	if !removing && len(table.Primaries) == 0 {
+		return errors.New("Returning requires a primary key on MySQL")
+	}
+
+	// Run in a transaction
+	if db.tx == nil {
+		db.Begin()
+
+		defer func() {
+			if err != nil {
+				_ = db.Rollback()
+			} else {
+				err = db.Commit()
+			}
+
+			db.tx = nil
+		}()
+	}
+
+	// Read the rows to remove
+	if removing {
+		if err = db.selectReturning(db.returning.columns, conditions, table, db.returning.dest); err != nil {
+			return
+		}
+
+		return db.execRaw(sqlStr, args)
+	}
+
+	// Lock the rows to update and keep their primary keys
+	var primaryKeys []string
+	for _, column := range table.Primaries {
+		primaryKeys = append(primaryKeys, column.Name)
+	}
+
+	var keys []map[string]any
+	if err = db.selectReturning(primaryKeys, conditions, table, &keys); err != nil {
+		return
+	}
+
+	if err = db.execRaw(sqlStr, args); err != nil {
+		return
+	}
+
+	if len(keys) == 0 {
+		return
+	}
+
+	// Read the updated rows back
+	var keyConditions []qb.Condition
+	for _, key := range keys {
+		var group []qb.Condition
+		for _, primaryKey := range primaryKeys {
+			group = append(group, qb.Condition{Field: primaryKey, Opt: Eq, Value: key[primaryKey], AndOr: And})
+		}
+
+		keyConditions = append(keyConditions, qb.Condition{Group: group, AndOr: Or})
+	}
+
+	rowsAffected := db.rowsAffected
+	err = db.selectReturning(db.returning.columns, keyConditions, table, db.returning.dest)
+	db.rowsAffected = rowsAffected
+
+	return
+}
+
+// selectReturning reads and locks the rows matching conditions into a destination.
+//
+// Parameters:
+//   - columns ([]string): The selected columns.
+//   - conditions ([]qb.Condition): The WHERE conditions.
+//   - table (*Table): The table of the rows.
+//   - dest (any): The *Struct, pointer to slice or nil receiving the rows.
+//
+// Returns:
+//   - error: Error encountered during execution, if any.
+func (db *DBModel) selectReturning(columns []string, conditions []qb.Condition, table *Table, dest any) error {
+	selectColumns := make([]any, len(columns))
+	for i, column := range columns {
+		selectColumns[i] = column
+	}
+
+	sqlStr, args, _ := qb.QueryInstance().
+		Select(selectColumns...).
+		From(table.Name).
+		WhereCondition(conditions...).
+		Sql()
+
+	rows, err := db.rowsRaw(context.Background(), sqlStr+" FOR UPDATE", args)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	if keys, ok := dest.(*[]map[string]any); ok {
+		for rows.Next() {
+			key := make(map[string]any)
+			if err = rows.MapScan(key); err != nil {
+				return err
+			}
+
+			*keys = append(*keys, key)
+		}
+
+		return rows.Err()
+	}
+
+	_, err = scanReturning(rows, dest)
+
+	return err
+}
+
+// scanReturning scans returned rows into a destination and counts them.
+//
+// Parameters:
+//   - rows (*sqlx.Rows): The returned rows.
+//   - dest (any): A *Struct receiving the first row, a pointer to slice receiving all rows, or nil.
+//
+// Returns:
+//   - int64: The number of returned rows.
+//   - error: Error encountered during scanning, if any.
+func scanReturning(rows *sqlx.Rows, dest any) (int64, error) {
+	value := reflect.ValueOf(dest)
+
+	// All rows into a slice of maps (schemaless)
+	if isMapSlice(dest) {
+		return scanMaps(rows, dest)
+	}
+
+	// All rows into a slice, which is emptied before
+	if value.Kind() == reflect.Ptr && value.Elem().Kind() == reflect.Slice {
+		if err := sqlx.StructScan(rows, dest); err != nil {
+			return 0, err
+		}
+
+		return int64(value.Elem().Len()), nil
+	}
+
+	// The first row into a struct
+	var count int64
+	for rows.Next() {
+		if count == 0 && isStructPointer(dest) {
+			if err := rows.StructScan(dest); err != nil {
+				return 0, err
+			}
+		}
+
+		count++
+	}
+
+	return count, rows.Err()
+}
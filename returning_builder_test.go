@@ -0,0 +1,262 @@
+package db
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+
+	qb "github.com/jivegroup/fluentsql"
+)
+
+type returningLog struct {
+	MetaData MetaData `db:"-" model:"table:logs"`
+	Message  string   `db:"message" model:"name:message"`
+}
+
+// userRows is the result of a query of the users.
+func userRows(users ...createUser) fakeResult {
+	result := fakeResult{columns: []string{"id", "email", "status"}}
+	for _, user := range users {
+		result.rows = append(result.rows, []driver.Value{int64(user.ID), user.Email, user.Status})
+	}
+
+	return result
+}
+
+func TestReturningUpdate(t *testing.T) {
+	tests := []struct {
+		name         string
+		dialect      qb.Dialect
+		results      []fakeResult
+		expected     []string
+		transactions []string
+	}{
+		{
+			name:     "PostgreSQL",
+			dialect:  new(qb.PostgreSQLDialect),
+			results:  []fakeResult{userRows(createUser{ID: 1, Email: "john@gfly.dev", Status: "active"})},
+			expected: []string{"UPDATE users SET email = $1, status = $2 WHERE id = $3 RETURNING *"},
+		},
+		{
+			name:     "SQLite",
+			dialect:  new(qb.SQLiteDialect),
+			results:  []fakeResult{userRows(createUser{ID: 1, Email: "john@gfly.dev", Status: "active"})},
+			expected: []string{"UPDATE users SET email = ?, status = ? WHERE id = ? RETURNING *"},
+		},
+		{
+			name:    "MySQL",
+			dialect: new(qb.MySQLDialect),
+			results: []fakeResult{
+				{columns: []string{"id"}, rows: [][]driver.Value{{int64(1)}}},
+				{affected: 1},
+				userRows(createUser{ID: 1, Email: "john@gfly.dev", Status: "active"}),
+			},
+			// The updated rows are locked, then read back by their primary keys
+			expected: []string{
+				"SELECT id FROM users WHERE id = ? FOR UPDATE",
+				"UPDATE users SET email = ?, status = ? WHERE id = ?",
+				"SELECT * FROM users WHERE (id = ?) FOR UPDATE",
+			},
+			transactions: []string{"BEGIN", "COMMIT"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeDB(t, tt.dialect, tt.results...)
+
+			db := Instance()
+			user := createUser{ID: 1, Email: "john@gfly.dev"}
+			if err := db.Returning().Update(&user); err != nil {
+				t.Fatal(err)
+			}
+
+			// The values computed by the database (e.g. by a trigger) are read back
+			if user.Status != "active" {
+				t.Errorf("user.Status = %q, expected active", user.Status)
+			}
+
+			if db.RowsAffected() != 1 {
+				t.Errorf("RowsAffected() = %d, expected 1", db.RowsAffected())
+			}
+
+			if !reflect.DeepEqual(fake.statements, tt.expected) {
+				t.Errorf("statements = %q, expected %q", fake.statements, tt.expected)
+			}
+
+			if !reflect.DeepEqual(fake.transactions, tt.transactions) {
+				t.Errorf("transactions = %q, expected %q", fake.transactions, tt.transactions)
+			}
+		})
+	}
+}
+
+func TestReturningUpdateMySQL(t *testing.T) {
+	t.Run("no row", func(t *testing.T) {
+		fake := useFakeDB(t, new(qb.MySQLDialect), fakeResult{columns: []string{"id"}})
+
+		db := Instance()
+		user := createUser{ID: 1, Email: "john@gfly.dev"}
+		if err := db.Returning("status").Update(&user); err != nil {
+			t.Fatal(err)
+		}
+
+		// Nothing to read back
+		if len(fake.statements) != 2 {
+			t.Errorf("statements = %q, expected the lock and the update", fake.statements)
+		}
+
+		if db.RowsAffected() != 0 {
+			t.Errorf("RowsAffected() = %d, expected 0", db.RowsAffected())
+		}
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		errUpdate := errors.New("update failed")
+
+		fake := useFakeDB(t, new(qb.MySQLDialect),
+			fakeResult{columns: []string{"id"}, rows: [][]driver.Value{{int64(1)}}},
+			fakeResult{err: errUpdate},
+		)
+
+		user := createUser{ID: 1, Email: "john@gfly.dev"}
+		if err := Instance().Returning().Update(&user); !errors.Is(err, errUpdate) {
+			t.Fatalf("Update() = %v, expected %v", err, errUpdate)
+		}
+
+		expected := []string{"BEGIN", "ROLLBACK"}
+		if !reflect.DeepEqual(fake.transactions, expected) {
+			t.Errorf("transactions = %q, expected %q", fake.transactions, expected)
+		}
+	})
+
+	t.Run("current transaction", func(t *testing.T) {
+		fake := useFakeDB(t, new(qb.MySQLDialect),
+			fakeResult{columns: []string{"id"}, rows: [][]driver.Value{{int64(1)}}},
+			fakeResult{affected: 1},
+			userRows(createUser{ID: 1, Email: "john@gfly.dev", Status: "active"}),
+		)
+
+		db := Instance().Begin()
+		user := createUser{ID: 1, Email: "john@gfly.dev"}
+		if err := db.Returning().Update(&user); err != nil {
+			t.Fatal(err)
+		}
+
+		// The statements run in the transaction, which is not committed
+		expected := []string{"BEGIN"}
+		if !reflect.DeepEqual(fake.transactions, expected) {
+			t.Errorf("transactions = %q, expected %q", fake.transactions, expected)
+		}
+
+		if err := db.Commit(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("without primary key", func(t *testing.T) {
+		fake := useFakeDB(t, new(qb.MySQLDialect))
+
+		entry := returningLog{Message: "updated"}
+		if err := Instance().Where("message", Eq, "created").Returning().Update(&entry); err == nil {
+			t.Error("Update() expected an error without primary key")
+		}
+
+		if len(fake.statements) != 0 {
+			t.Errorf("statements = %q, expected none", fake.statements)
+		}
+	})
+}
+
+func TestReturningDelete(t *testing.T) {
+	deleted := userRows(
+		createUser{ID: 1, Email: "john@gfly.dev", Status: "inactive"},
+		createUser{ID: 2, Email: "jane@gfly.dev", Status: "inactive"},
+	)
+
+	tests := []struct {
+		name         string
+		dialect      qb.Dialect
+		results      []fakeResult
+		expected     []string
+		transactions []string
+	}{
+		{
+			name:     "PostgreSQL",
+			dialect:  new(qb.PostgreSQLDialect),
+			results:  []fakeResult{deleted},
+			expected: []string{"DELETE FROM users WHERE status = $1 RETURNING *"},
+		},
+		{
+			name:    "MySQL",
+			dialect: new(qb.MySQLDialect),
+			results: []fakeResult{deleted, {affected: 2}},
+			// The deleted rows are read before the statement
+			expected: []string{
+				"SELECT * FROM users WHERE status = ? FOR UPDATE",
+				"DELETE FROM users WHERE status = ?",
+			},
+			transactions: []string{"BEGIN", "COMMIT"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeDB(t, tt.dialect, tt.results...)
+
+			db := Instance()
+			var users []createUser
+			if err := db.Where("status", Eq, "inactive").Returning().Delete(&users); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(users) != 2 || users[0].Email != "john@gfly.dev" || users[1].ID != 2 {
+				t.Errorf("users = %+v, expected the deleted users", users)
+			}
+
+			if db.RowsAffected() != 2 {
+				t.Errorf("RowsAffected() = %d, expected 2", db.RowsAffected())
+			}
+
+			if !reflect.DeepEqual(fake.statements, tt.expected) {
+				t.Errorf("statements = %q, expected %q", fake.statements, tt.expected)
+			}
+
+			if !reflect.DeepEqual(fake.transactions, tt.transactions) {
+				t.Errorf("transactions = %q, expected %q", fake.transactions, tt.transactions)
+			}
+		})
+	}
+}
+
+func TestReturningDeleteModel(t *testing.T) {
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect), fakeResult{columns: []string{"status"}, rows: [][]driver.Value{{"active"}}})
+
+	user := createUser{ID: 1}
+	if err := Instance().Returning("status").Delete(&user); err != nil {
+		t.Fatal(err)
+	}
+
+	if user.Status != "active" {
+		t.Errorf("user.Status = %q, expected active", user.Status)
+	}
+
+	expected := "DELETE FROM users WHERE id = $1 RETURNING status"
+	if len(fake.statements) != 1 || fake.statements[0] != expected {
+		t.Errorf("statements = %q, expected %q", fake.statements, expected)
+	}
+}
+
+func TestDeleteSliceRequiresReturning(t *testing.T) {
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect))
+
+	var users []createUser
+	if err := Instance().Where("status", Eq, "inactive").Delete(&users); err == nil {
+		t.Error("Delete() of a *Slice expected an error without Returning")
+	}
+
+	if len(fake.statements) != 0 {
+		t.Errorf("statements = %q, expected none", fake.statements)
+	}
+}
@@ -0,0 +1,54 @@
+package db
+
+// ====================================================================
+//                            Rows affected
+// ====================================================================
+
+// RowsAffected returns the number of rows affected by the last INSERT, UPDATE or DELETE statement.
+// It is kept after the builder is reset, so it can be read after Create, Update or Delete.
+//
+// Returns:
+//   - int64: The number of affected rows.
+//
+// Example:
+//
+//	db := mb.Instance()
+//	err := db.Where("status", mb.Eq, "pending").Delete(&Order{})
+//	log.Printf("Deleted %d orders", db.RowsAffected())
+func (db *DBModel) RowsAffected() int64 {
+	return db.rowsAffected
+}
+
+// Strict makes the next Update, UpdateColumns, Increment, Decrement or Delete return ErrNoRowsAffected
+// when its statement affects no row, e.g. when the model was deleted meanwhile.
+// An Update skipped because no column changed (see DirtyTracker) executes nothing and returns nil,
+// without checking that the row still exists.
+// On MySQL, an update writing the same values affects no row and fails, unless the connection reports
+// the matched rows (`clientFoundRows=true`, set by the mysql driver when DB_CLIENT_FOUND_ROWS is true).
+//
+// Returns:
+//   - *DBModel: A reference to the DBModel instance for chaining.
+//
+// Example:
+//
+//	err := mb.Instance().Strict().Update(&user)
+//	if errors.Is(err, mb.ErrNoRowsAffected) {
+//	    // The user doesn't exist anymore
+//	}
+func (db *DBModel) Strict() *DBModel {
+	db.strict = true
+
+	return db
+}
+
+// strictRowsAffected checks the number of rows affected by the last statement in strict mode.
+//
+// Returns:
+//   - error: ErrNoRowsAffected in strict mode when no row was affected; nil otherwise.
+func (db *DBModel) strictRowsAffected() error {
+	if db.strict && db.rowsAffected == 0 {
+		return ErrNoRowsAffected
+	}
+
+	return nil
+}
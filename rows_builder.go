@@ -0,0 +1,174 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"iter"
+	"reflect"
+	"time"
+)
+
+// ====================================================================
+//                          Streaming rows
+// ====================================================================
+
+// Rows executes the query and returns a cursor over the resulting rows, which are read one at a
+// time instead of being loaded into a slice. WHERE, JOIN, GROUP BY, HAVING, ORDER BY, LIMIT, FETCH
+// and row locking clauses are applied. The caller must close the rows.
+// Inside a transaction, the rows must be closed before running another query of the transaction.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the query. Cancelling it stops the reading.
+//
+// Returns:
+//   - *sqlx.Rows: The cursor over the rows (use Next, StructScan and Close).
+//   - error: An error object if any issues occur during the query; nil otherwise.
+//
+// Example:
+//
+//	rows, err := mb.Instance().Model(&User{}).Where("status", mb.Eq, "active").Rows(ctx)
+//	if err != nil {
+//	    return err
+//	}
+//	defer rows.Close()
+//
+//	for rows.Next() {
+//	    var user User
+//	    if err := rows.StructScan(&user); err != nil {
+//	        return err
+//	    }
+//	}
+//	return rows.Err()
+func (db *DBModel) Rows(ctx context.Context) (*sqlx.Rows, error) {
+	defer db.reset()
+
+	queryBuilder, _, err := db.baseQuery(db.selectColumns()...)
+	if err != nil {
+		return nil, err
+	}
+
+	db.paginateQuery(queryBuilder)
+
+	sqlStr, args := db.selectSql(queryBuilder)
+
+	return db.rowsRaw(ctx, sqlStr, args)
+}
+
+// Each executes the query and returns an iterator scanning one row of type T at a time.
+// The rows are closed when the loop ends, including on break. The iterator stops after
+// yielding an error. It can be iterated once, because the query of the DBModel is reset.
+//
+// Generic Type:
+//   - T: The type of the rows: a struct, a pointer to a struct or a single column type
+//     (e.g. int, string, time.Time or a sql.Scanner such as sql.NullString).
+//
+// Parameters:
+//   - ctx (context.Context): The context of the query. Cancelling it stops the iteration.
+//   - db (*DBModel): The query to execute. The model defaults to a struct T. A single column type
+//     requires Model or Table, and a Select of the column.
+//
+// Returns:
+//   - iter.Seq2[T, error]: The iterator over the rows.
+//
+// Example:
+//
+//	for user, err := range mb.Each[User](ctx, mb.Instance().Where("status", mb.Eq, "active")) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    writer.Write(user.Email)
+//	}
+//
+//	for email, err := range mb.Each[string](ctx, mb.Instance().Model(&User{}).Select("email")) {
+//	    ...
+//	}
+func Each[T any](ctx context.Context, db *DBModel) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		// Take the model from the type of the rows
+		if db.model == nil && !isColumnType(reflect.TypeFor[T]()) {
+			db.model = reflect.New(scopeModelType(reflect.TypeFor[T]())).Interface()
+		}
+
+		rows, err := db.Rows(ctx)
+		if err != nil {
+			yield(zero, err)
+
+			return
+		}
+		defer func() {
+			_ = rows.Close()
+		}()
+
+		for rows.Next() {
+			item, err := scanRow[T](rows)
+			if err != nil {
+				yield(zero, err)
+
+				return
+			}
+
+			if !yield(item, nil) {
+				return
+			}
+		}
+
+		if err = rows.Err(); err != nil {
+			yield(zero, err)
+		}
+	}
+}
+
+// scanRow scans the current row into a value of type T.
+// Structs and pointers to structs are scanned by column name, other types from a single column.
+//
+// Generic Type:
+//   - T: The type of the row.
+//
+// Parameters:
+//   - rows (*sqlx.Rows): The rows positioned on the row to scan.
+//
+// Returns:
+//   - T: The scanned row.
+//   - error: An error object if the row can't be scanned; nil otherwise.
+func scanRow[T any](rows *sqlx.Rows) (item T, err error) {
+	typ := reflect.TypeFor[T]()
+
+	switch {
+	case isColumnType(typ):
+		err = rows.Scan(&item)
+	case typ.Kind() == reflect.Struct:
+		err = rows.StructScan(&item)
+	case typ.Kind() == reflect.Ptr && typ.Elem().Kind() == reflect.Struct:
+		value := reflect.New(typ.Elem())
+		if err = rows.StructScan(value.Interface()); err == nil {
+			item = value.Interface().(T)
+		}
+	default:
+		err = rows.Scan(&item)
+	}
+
+	return
+}
+
+// isColumnType reports whether a type holds a single column: a type other than a struct or a pointer
+// to a struct, time.Time, or a type scanning itself (e.g. sql.NullString).
+//
+// Parameters:
+//   - typ (reflect.Type): The type of the row.
+//
+// Returns:
+//   - bool: True if the type is scanned from a single column.
+func isColumnType(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct {
+		return true
+	}
+
+	return typ == reflect.TypeFor[time.Time]() || reflect.PointerTo(typ).Implements(reflect.TypeFor[sql.Scanner]())
+}
@@ -0,0 +1,261 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	qb "github.com/jivegroup/fluentsql"
+)
+
+func TestRows(t *testing.T) {
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect), fakeResult{
+		columns: []string{"id", "title"},
+		rows:    [][]driver.Value{{int64(1), "a"}, {int64(2), "b"}},
+	})
+
+	rows, err := Instance().Model(&unionPost{}).Where("id", Greater, 0).OrderBy("id", Asc).Limit(2, 0).
+		Rows(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var posts []unionPost
+	for rows.Next() {
+		var post unionPost
+		if err := rows.StructScan(&post); err != nil {
+			t.Fatal(err)
+		}
+		posts = append(posts, post)
+	}
+
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPosts := []unionPost{{ID: 1, Title: "a"}, {ID: 2, Title: "b"}}
+	if !reflect.DeepEqual(posts, expectedPosts) {
+		t.Errorf("posts = %+v, expected %+v", posts, expectedPosts)
+	}
+
+	expected := "SELECT * FROM posts WHERE id > $1 ORDER BY id ASC LIMIT $2 OFFSET $3"
+	if len(fake.statements) != 1 || fake.statements[0] != expected {
+		t.Errorf("statements = %q, expected %q", fake.statements, expected)
+	}
+}
+
+func TestRowsWithoutModel(t *testing.T) {
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect))
+
+	if _, err := Instance().Rows(context.Background()); err == nil {
+		t.Error("Rows() without model expected an error")
+	}
+
+	if len(fake.statements) != 0 {
+		t.Errorf("statements = %q, expected none", fake.statements)
+	}
+}
+
+// collect reads every row of an iterator, stopping at the first error.
+func collect[T any](ctx context.Context, db *DBModel) ([]T, error) {
+	var items []T
+	for item, err := range Each[T](ctx, db) {
+		if err != nil {
+			return items, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func TestEach(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		run      func() (any, error)
+		result   fakeResult
+		expected string
+		items    any
+	}{
+		{
+			name: "struct",
+			run: func() (any, error) {
+				return collect[unionPost](ctx, Instance().Where("id", Greater, 0))
+			},
+			result: fakeResult{
+				columns: []string{"id", "title"},
+				rows:    [][]driver.Value{{int64(1), "a"}, {int64(2), "b"}},
+			},
+			expected: "SELECT * FROM posts WHERE id > $1",
+			items:    []unionPost{{ID: 1, Title: "a"}, {ID: 2, Title: "b"}},
+		},
+		{
+			name: "pointer to struct",
+			run: func() (any, error) {
+				return collect[*unionPost](ctx, Instance())
+			},
+			result: fakeResult{
+				columns: []string{"id", "title"},
+				rows:    [][]driver.Value{{int64(1), "a"}},
+			},
+			expected: "SELECT * FROM posts",
+			items:    []*unionPost{{ID: 1, Title: "a"}},
+		},
+		{
+			name: "single column of a model",
+			run: func() (any, error) {
+				return collect[string](ctx, Instance().Model(&unionPost{}).Select("title"))
+			},
+			result: fakeResult{
+				columns: []string{"title"},
+				rows:    [][]driver.Value{{"a"}, {"b"}},
+			},
+			expected: "SELECT title FROM posts",
+			items:    []string{"a", "b"},
+		},
+		{
+			name: "single column of a table",
+			run: func() (any, error) {
+				return collect[int64](ctx, Instance().Table("posts").Select("id"))
+			},
+			result: fakeResult{
+				columns: []string{"id"},
+				rows:    [][]driver.Value{{int64(3)}},
+			},
+			expected: "SELECT id FROM posts",
+			items:    []int64{3},
+		},
+		{
+			name: "time column",
+			run: func() (any, error) {
+				return collect[time.Time](ctx, Instance().Model(&unionPost{}).Select("created_at"))
+			},
+			result: fakeResult{
+				columns: []string{"created_at"},
+				rows:    [][]driver.Value{{createdAt}},
+			},
+			expected: "SELECT created_at FROM posts",
+			items:    []time.Time{createdAt},
+		},
+		{
+			name: "scanner column",
+			run: func() (any, error) {
+				return collect[sql.NullString](ctx, Instance().Model(&unionPost{}).Select("title"))
+			},
+			result: fakeResult{
+				columns: []string{"title"},
+				rows:    [][]driver.Value{{nil}, {"a"}},
+			},
+			expected: "SELECT title FROM posts",
+			items:    []sql.NullString{{}, {String: "a", Valid: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeDB(t, new(qb.PostgreSQLDialect), tt.result)
+
+			items, err := tt.run()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(items, tt.items) {
+				t.Errorf("items = %#v, expected %#v", items, tt.items)
+			}
+
+			if len(fake.statements) != 1 || fake.statements[0] != tt.expected {
+				t.Errorf("statements = %q, expected %q", fake.statements, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEachBreak(t *testing.T) {
+	ctx := context.Background()
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect),
+		fakeResult{columns: []string{"id", "title"}, rows: [][]driver.Value{{int64(1), "a"}, {int64(2), "b"}}},
+		fakeResult{columns: []string{"result"}, rows: [][]driver.Value{{int64(2)}}},
+	)
+
+	var count int
+	for _, err := range Each[unionPost](ctx, Instance()) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		count++
+
+		break
+	}
+
+	if count != 1 {
+		t.Errorf("count = %d, expected 1", count)
+	}
+
+	// The only connection is free again: the rows were closed on break
+	if _, err := Instance().Model(&unionPost{}).Count(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fake.statements) != 2 {
+		t.Errorf("statements = %q, expected 2", fake.statements)
+	}
+}
+
+func TestEachErrors(t *testing.T) {
+	ctx := context.Background()
+	errQuery := errors.New("query failed")
+
+	tests := []struct {
+		name    string
+		run     func() ([]int64, error)
+		results []fakeResult
+	}{
+		{
+			name: "single column without model",
+			run: func() ([]int64, error) {
+				return collect[int64](ctx, Instance().Select("id"))
+			},
+		},
+		{
+			name: "failed query",
+			run: func() ([]int64, error) {
+				return collect[int64](ctx, Instance().Table("posts").Select("id"))
+			},
+			results: []fakeResult{{err: errQuery}},
+		},
+		{
+			name: "unscannable row",
+			run: func() ([]int64, error) {
+				return collect[int64](ctx, Instance().Table("posts").Select("id"))
+			},
+			results: []fakeResult{{columns: []string{"id"}, rows: [][]driver.Value{{int64(1)}, {"x"}}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useFakeDB(t, new(qb.PostgreSQLDialect), tt.results...)
+
+			items, err := tt.run()
+			if err == nil {
+				t.Fatal("Each() expected an error")
+			}
+
+			// The iterator stops after the error
+			if len(items) > 1 {
+				t.Errorf("items = %v, expected at most one", items)
+			}
+		})
+	}
+}
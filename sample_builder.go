@@ -0,0 +1,324 @@
+package db
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+	"github.com/gflydev/core/errors"
+	qb "github.com/jivegroup/fluentsql"
+	"math/big"
+	"reflect"
+	"strconv"
+)
+
+// ====================================================================
+//                          Random sampling
+// ====================================================================
+
+// SampleStrategy represents how TakeOne and TakeN select random rows.
+type SampleStrategy int
+
+// Sample strategy constants
+const (
+	// SampleRandomOrder sorts the rows with `ORDER BY RANDOM()` (`RAND()` for MySQL).
+	// Every row has the same chance, but the whole result is sorted: use it for small tables.
+	// It is the default strategy.
+	SampleRandomOrder SampleStrategy = iota
+
+	// SampleTableSample reads a Bernoulli sample of the table with `TABLESAMPLE BERNOULLI (p)`,
+	// where p is derived from the table statistics, then sorts the sample randomly (PostgreSQL).
+	// The random order is used by other dialects, when no statistics are available, or when
+	// the sample has fewer rows than requested.
+	SampleTableSample
+
+	// SampleRandomOffset counts the rows, then reads each row at a random offset of the primary
+	// key order. It runs n + 1 queries, and each one reads the rows up to its offset: only from
+	// the primary key index when the query has no WHERE, JOIN or soft delete condition. Use it
+	// for large tables and a few rows, e.g. with Take. The random order is used when more than
+	// randomOffsetMaxRows rows are requested, or more than a tenth of the matching rows.
+	SampleRandomOffset
+)
+
+const (
+	// tableSampleFactor is how many more rows than requested a table sample reads, so that the
+	// conditions of the query still leave enough rows.
+	tableSampleFactor = 10
+
+	// randomOffsetMaxRows is the largest sample read at random offsets. Larger samples are sorted
+	// randomly, which reads the rows once instead of once per sampled row.
+	randomOffsetMaxRows = 10
+
+	// randomOffsetFactor is how many more matching rows than requested random offsets need.
+	// Smaller results are sorted randomly.
+	randomOffsetFactor = 10
+)
+
+// WithSample sets how the next TakeOne or TakeN selects random rows.
+//
+// Parameters:
+//   - strategy (SampleStrategy): The sample strategy (SampleRandomOrder, SampleTableSample or SampleRandomOffset).
+//
+// Returns:
+//   - *DBModel: A reference to the DBModel instance for chaining.
+//
+// Example:
+//
+//	var user User
+//	err := mb.Instance().WithSample(mb.SampleRandomOffset).Take(&user)
+func (db *DBModel) WithSample(strategy SampleStrategy) *DBModel {
+	db.sampleStrategy = strategy
+
+	return db
+}
+
+// Take retrieves a random record.
+//
+// Parameters:
+//   - model (any): A pointer to the model where the result will be stored.
+//
+// Returns:
+//   - err (error): sql.ErrNoRows if no row matches, or an error object if any issues occur during
+//     the retrieval process; nil otherwise.
+func (db *DBModel) Take(model any) (err error) {
+	err = db.Get(model, TakeOne)
+	return
+}
+
+// TakeN retrieves up to n distinct random rows matching the query.
+// WHERE, JOIN, GROUP BY and HAVING clauses are applied; ORDER BY, LIMIT and FETCH are replaced.
+//
+// Parameters:
+//   - n (int): The number of rows to sample.
+//   - items (any): A pointer to the slice where the rows will be stored.
+//
+// Returns:
+//   - error: An error object if any issues occur during the query; nil otherwise.
+//
+// Example:
+//
+//	var users []User
+//	err := mb.Instance().Where("status", mb.Eq, "active").TakeN(100, &users)
+//	// Executes: SELECT * FROM users WHERE status = $1 ORDER BY RANDOM() ASC LIMIT 100 OFFSET 0
+func (db *DBModel) TakeN(n int, items any) error {
+	defer db.reset()
+
+	if db.raw.sqlStr != "" {
+		return errors.New("Raw SQL queries are not supported by TakeN")
+	}
+
+	typ := reflect.TypeOf(items)
+	if typ == nil || !(typ.Kind() == reflect.Ptr && typ.Elem().Kind() == reflect.Slice) {
+		return errors.New("Invalid data :: items not *Slice type")
+	}
+
+	if n < 1 {
+		return errors.New("Invalid n %d :: n must be greater than 0", n)
+	}
+
+	// Take the model from the items when it is not set
+	if db.model == nil {
+		db.model = reflect.New(scopeModelType(typ)).Interface()
+	}
+
+	return db.sample(context.Background(), n, items)
+}
+
+// takeOne retrieves a random record into the model. The model gives the table and conditions.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the queries.
+//   - model (any): A pointer to the model where the result will be stored.
+//
+// Returns:
+//   - error: sql.ErrNoRows if no row matches, or an error object if any issues occur; nil otherwise.
+func (db *DBModel) takeOne(ctx context.Context, model any) error {
+	defer db.reset()
+
+	modelValue := reflect.ValueOf(model)
+	if modelValue.Kind() != reflect.Ptr {
+		return errors.New("Invalid data :: model not *Struct type")
+	}
+
+	db.model = model
+
+	items := reflect.New(reflect.SliceOf(modelValue.Type().Elem()))
+	if err := db.sample(ctx, 1, items.Interface()); err != nil {
+		return err
+	}
+
+	if items.Elem().Len() == 0 {
+		return sql.ErrNoRows
+	}
+
+	modelValue.Elem().Set(items.Elem().Index(0))
+
+	return nil
+}
+
+// sample retrieves up to n random rows with the sample strategy of the query.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the queries.
+//   - n (int): The number of rows to sample.
+//   - items (any): A pointer to the slice where the rows will be stored.
+//
+// Returns:
+//   - error: An error object if any issues occur during the queries; nil otherwise.
+func (db *DBModel) sample(ctx context.Context, n int, items any) error {
+	switch db.sampleStrategy {
+	case SampleRandomOffset:
+		if n <= randomOffsetMaxRows {
+			return db.sampleRandomOffset(ctx, n, items)
+		}
+	case SampleTableSample:
+		if qb.IsDialect(qb.PostgreSQL) {
+			sampled, err := db.sampleTable(ctx, n, items)
+			if err != nil || sampled {
+				return err
+			}
+		}
+	}
+
+	return db.sampleRandomOrder(ctx, n, items, "")
+}
+
+// sampleRandomOrder retrieves up to n rows sorted randomly.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the query.
+//   - n (int): The number of rows to sample.
+//   - items (any): A pointer to the slice where the rows will be stored.
+//   - from (string): The FROM source replacing the table (e.g. a TABLESAMPLE clause). Empty for the table.
+//
+// Returns:
+//   - error: An error object if any issues occur during the query; nil otherwise.
+func (db *DBModel) sampleRandomOrder(ctx context.Context, n int, items any, from string) error {
+	queryBuilder, _, err := db.baseQuery(db.selectColumns()...)
+	if err != nil {
+		return err
+	}
+
+	if from != "" {
+		queryBuilder.From(from)
+	}
+
+	randomFunction := "RANDOM()"
+	if qb.IsDialect(qb.MySQL) {
+		randomFunction = "RAND()"
+	}
+
+	queryBuilder.OrderBy(randomFunction, Asc).Limit(n, 0)
+
+	sqlStr, args := db.selectSql(queryBuilder)
+
+	return db.queryRawContext(ctx, sqlStr, args, items)
+}
+
+// sampleTable retrieves up to n random rows from a Bernoulli sample of a PostgreSQL table.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the queries.
+//   - n (int): The number of rows to sample.
+//   - items (any): A pointer to the slice where the rows will be stored.
+//
+// Returns:
+//   - bool: False when the table sample can't give n rows and another strategy must be used.
+//   - error: An error object if any issues occur during the queries; nil otherwise.
+func (db *DBModel) sampleTable(ctx context.Context, n int, items any) (bool, error) {
+	table, err := ModelData(db.model)
+	if err != nil {
+		return false, err
+	}
+
+	estimate, ok, err := db.tableRowsEstimate(ctx, table)
+	if err != nil || !ok || estimate == 0 {
+		return false, err
+	}
+
+	percent := float64(n*tableSampleFactor) / estimate * 100
+	if percent >= 100 {
+		return false, nil
+	}
+
+	from := fmt.Sprintf("%s TABLESAMPLE BERNOULLI (%s)", table.Name, strconv.FormatFloat(percent, 'f', -1, 64))
+	if err = db.sampleRandomOrder(ctx, n, items, from); err != nil {
+		return false, err
+	}
+
+	// Too few rows in the sample: discard it
+	slice := reflect.ValueOf(items).Elem()
+	if slice.Len() < n {
+		slice.Set(reflect.Zero(slice.Type()))
+
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// sampleRandomOffset retrieves up to n rows, each at a distinct random offset of the primary key order.
+// The rows are sorted randomly instead when n is a large fraction of the matching rows.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the queries.
+//   - n (int): The number of rows to sample.
+//   - items (any): A pointer to the slice where the rows will be stored.
+//
+// Returns:
+//   - error: An error object if any issues occur during the queries; nil otherwise.
+func (db *DBModel) sampleRandomOffset(ctx context.Context, n int, items any) error {
+	queryBuilder, table, err := db.baseQuery(db.selectColumns()...)
+	if err != nil {
+		return err
+	}
+
+	var total int
+	if err = db.count(ctx, queryBuilder, &total); err != nil {
+		return err
+	}
+
+	if total == 0 {
+		return nil
+	}
+
+	// Reading most rows at offsets would cost more than sorting them
+	if n*randomOffsetFactor > total {
+		return db.sampleRandomOrder(ctx, n, items, "")
+	}
+
+	// A stable order to address the rows by offset
+	if len(table.Primaries) > 0 {
+		queryBuilder.OrderBy(table.Name+"."+table.Primaries[0].Name, Asc)
+	} else {
+		queryBuilder.OrderBy(table.Name+"."+table.Columns[0].Name, Asc)
+	}
+
+	slice := reflect.ValueOf(items).Elem()
+	row := reflect.New(slice.Type())
+	picked := make(map[int64]bool, n)
+
+	for len(picked) < min(n, total) {
+		offset, err := rand.Int(rand.Reader, big.NewInt(int64(total)))
+		if err != nil {
+			return err
+		}
+
+		if picked[offset.Int64()] {
+			continue
+		}
+		picked[offset.Int64()] = true
+
+		queryBuilder.Limit(1, int(offset.Int64()))
+		sqlStr, args := db.selectSql(queryBuilder)
+
+		row.Elem().Set(reflect.Zero(slice.Type()))
+		if err = db.queryRawContext(ctx, sqlStr, args, row.Interface()); err != nil {
+			return err
+		}
+
+		slice.Set(reflect.AppendSlice(slice, row.Elem()))
+	}
+
+	return nil
+}
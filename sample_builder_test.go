@@ -0,0 +1,75 @@
+package db
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"testing"
+
+	qb "github.com/jivegroup/fluentsql"
+)
+
+func TestSampleRandomOffset(t *testing.T) {
+	total := func(count int64) fakeResult {
+		return fakeResult{columns: []string{"total"}, rows: [][]driver.Value{{count}}}
+	}
+	row := fakeResult{columns: []string{"id"}, rows: [][]driver.Value{{int64(7)}}}
+
+	tests := []struct {
+		name     string
+		n        int
+		results  []fakeResult
+		expected []string
+	}{
+		{
+			name:    "few rows of a large result",
+			n:       2,
+			results: []fakeResult{total(1000), row, row},
+			expected: []string{
+				"SELECT COUNT(*)",
+				"SELECT * FROM users ORDER BY users.id ASC LIMIT $1 OFFSET $2",
+				"SELECT * FROM users ORDER BY users.id ASC LIMIT $1 OFFSET $2",
+			},
+		},
+		{
+			name:    "large fraction of the result",
+			n:       2,
+			results: []fakeResult{total(15), row},
+			expected: []string{
+				"SELECT COUNT(*)",
+				"SELECT * FROM users ORDER BY RANDOM() ASC LIMIT $1 OFFSET $2",
+			},
+		},
+		{
+			name:    "too many rows",
+			n:       randomOffsetMaxRows + 1,
+			results: []fakeResult{row},
+			expected: []string{
+				"SELECT * FROM users ORDER BY RANDOM() ASC LIMIT $1 OFFSET $2",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeDB(t, new(qb.PostgreSQLDialect), tt.results...)
+
+			var users []chunkUser
+			if err := Instance().WithSample(SampleRandomOffset).TakeN(tt.n, &users); err != nil {
+				t.Fatal(err)
+			}
+
+			statements := make([]string, len(fake.statements))
+			for i, statement := range fake.statements {
+				statements[i] = statement
+				if strings.HasPrefix(statement, "SELECT COUNT(*)") {
+					statements[i] = "SELECT COUNT(*)"
+				}
+			}
+
+			if !reflect.DeepEqual(statements, tt.expected) {
+				t.Errorf("statements = %q, expected %q", fake.statements, tt.expected)
+			}
+		})
+	}
+}
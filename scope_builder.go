@@ -0,0 +1,292 @@
+package db
+
+import (
+	qb "github.com/jivegroup/fluentsql"
+	"reflect"
+	"sync"
+)
+
+// ====================================================================
+//                              Scopes
+// ====================================================================
+
+// Scope represents a reusable piece of query logic applied to a DBModel.
+// A scope can use any builder method such as Where, WhereGroup, When, Join, OrderBy or Limit.
+//
+// Example:
+//
+//	func ActiveUsers(db *mb.DBModel) *mb.DBModel {
+//	    return db.Where("status", mb.Eq, "active").Where("deleted_at", mb.Null, nil)
+//	}
+//
+//	func InOrg(orgID int) mb.Scope {
+//	    return func(db *mb.DBModel) *mb.DBModel {
+//	        return db.Where("org_id", mb.Eq, orgID)
+//	    }
+//	}
+type Scope func(db *DBModel) *DBModel
+
+// Scopes applies the given scopes to the query, in order.
+// The WHERE conditions added by a scope are enclosed in parentheses when they contain an OR
+// condition, so that a scope never changes the meaning of the conditions of other scopes.
+//
+// Parameters:
+//   - scopes (...Scope): The scopes to apply.
+//
+// Returns:
+//   - *DBModel: A reference to the DBModel instance for chaining.
+//
+// Example:
+//
+//	var users []User
+//	total, err := mb.Instance().
+//	    Scopes(ActiveUsers, InOrg(orgID), Paginate(page, size)).
+//	    Find(&users)
+func (db *DBModel) Scopes(scopes ...Scope) *DBModel {
+	for _, scope := range scopes {
+		if scope == nil {
+			continue
+		}
+
+		start := len(db.whereStatement.Conditions)
+
+		scope(db)
+		db.groupConditionsFrom(start)
+	}
+
+	return db
+}
+
+// WhereScope creates a scope from a list of conditions combined with AND.
+//
+// Parameters:
+//   - conditions (...Condition): The conditions of the scope.
+//
+// Returns:
+//   - Scope: The scope adding the conditions to the query.
+func WhereScope(conditions ...Condition) Scope {
+	return func(db *DBModel) *DBModel {
+		for _, condition := range conditions {
+			conditionConvert := condition.ToQBCondition()
+			db.Where(conditionConvert.Field, conditionConvert.Opt, conditionConvert.Value)
+		}
+
+		return db
+	}
+}
+
+// groupConditionsFrom encloses the WHERE conditions added since the given position in a group
+// when one of them is combined with OR.
+//
+// Parameters:
+//   - start (int): The position of the first condition to group.
+func (db *DBModel) groupConditionsFrom(start int) {
+	conditions := db.whereStatement.Conditions
+	if start >= len(conditions) {
+		return
+	}
+
+	db.whereStatement.Conditions = append(conditions[:start:start], groupConditions(conditions[start:])...)
+}
+
+// groupConditions encloses the conditions in a group when one of them is combined with OR,
+// so that conditions appended afterward restrict all of them.
+//
+// Parameters:
+//   - conditions ([]qb.Condition): The conditions to group.
+//
+// Returns:
+//   - []qb.Condition: The conditions unchanged or a single group condition.
+func groupConditions(conditions []qb.Condition) []qb.Condition {
+	hasOr := false
+	for _, condition := range conditions {
+		if condition.AndOr == Or {
+			hasOr = true
+			break
+		}
+	}
+
+	if !hasOr {
+		return conditions
+	}
+
+	group := make([]qb.Condition, len(conditions))
+	copy(group, conditions)
+
+	// The first condition of a group has no preceding condition to combine with
+	group[0].AndOr = And
+
+	return []qb.Condition{{
+		Group: group,
+	}}
+}
+
+// ====================================================================
+//                           Global scopes
+// ====================================================================
+
+// globalScope represents a named scope registered for a model type.
+type globalScope struct {
+	name  string
+	scope Scope
+}
+
+var (
+	// globalScopes holds the global scopes registered per model type, in registration order.
+	globalScopes = make(map[reflect.Type][]globalScope)
+
+	// globalScopesMutex guards globalScopes.
+	globalScopesMutex sync.RWMutex
+)
+
+// globalScopeState represents the global scopes options of a query.
+//
+// Fields:
+//   - excluded (map[string]bool): The names of the global scopes disabled for the query.
+//   - excludeAll (bool): Whether all global scopes are disabled for the query.
+//   - applied (bool): Whether the global scopes were already applied to the query.
+type globalScopeState struct {
+	excluded   map[string]bool
+	excludeAll bool
+	applied    bool
+}
+
+// AddGlobalScope registers a scope applied to every query built from the model type T
+// (First, Last, Get, Find, ToQueryBuilder, Update and Delete). Registering a scope with
+// an existing name replaces it. Global scopes are usually registered at startup.
+//
+// Generic Type:
+//   - T: The model struct type.
+//
+// Parameters:
+//   - name (string): The name of the scope, used by WithoutGlobalScope.
+//   - scope (Scope): The scope to apply.
+//
+// Example:
+//
+//	mb.AddGlobalScope[models.User]("tenant", func(db *mb.DBModel) *mb.DBModel {
+//	    return db.Where("tenant_id", mb.Eq, currentTenantID())
+//	})
+func AddGlobalScope[T any](name string, scope Scope) {
+	typ := scopeModelType(reflect.TypeFor[T]())
+
+	globalScopesMutex.Lock()
+	defer globalScopesMutex.Unlock()
+
+	for i, item := range globalScopes[typ] {
+		if item.name == name {
+			globalScopes[typ][i].scope = scope
+
+			return
+		}
+	}
+
+	globalScopes[typ] = append(globalScopes[typ], globalScope{
+		name:  name,
+		scope: scope,
+	})
+}
+
+// RemoveGlobalScope unregisters a global scope of the model type T.
+//
+// Generic Type:
+//   - T: The model struct type.
+//
+// Parameters:
+//   - name (string): The name of the scope.
+func RemoveGlobalScope[T any](name string) {
+	typ := scopeModelType(reflect.TypeFor[T]())
+
+	globalScopesMutex.Lock()
+	defer globalScopesMutex.Unlock()
+
+	items := globalScopes[typ]
+	for i, item := range items {
+		if item.name == name {
+			globalScopes[typ] = append(items[:i:i], items[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// WithoutGlobalScope disables the given global scopes for the next operation.
+//
+// Parameters:
+//   - names (...string): The names of the global scopes to disable.
+//
+// Returns:
+//   - *DBModel: A reference to the DBModel instance for chaining.
+//
+// Example:
+//
+//	var users []User
+//	total, err := mb.Instance().WithoutGlobalScope("tenant").Find(&users)
+func (db *DBModel) WithoutGlobalScope(names ...string) *DBModel {
+	if db.globalScopes.excluded == nil {
+		db.globalScopes.excluded = make(map[string]bool, len(names))
+	}
+
+	for _, name := range names {
+		db.globalScopes.excluded[name] = true
+	}
+
+	return db
+}
+
+// WithoutGlobalScopes disables all global scopes for the next operation.
+//
+// Returns:
+//   - *DBModel: A reference to the DBModel instance for chaining.
+func (db *DBModel) WithoutGlobalScopes() *DBModel {
+	db.globalScopes.excludeAll = true
+
+	return db
+}
+
+// applyGlobalScopes applies the global scopes registered for the type of the model.
+// The WHERE conditions of the query are enclosed in parentheses when they contain an OR
+// condition, so that global scopes always restrict the whole query. Global scopes are
+// applied once per operation.
+//
+// Parameters:
+//   - model (any): The model, a pointer to the model or a pointer to a slice of models.
+func (db *DBModel) applyGlobalScopes(model any) {
+	if db.globalScopes.applied || db.globalScopes.excludeAll || model == nil {
+		return
+	}
+
+	db.globalScopes.applied = true
+
+	globalScopesMutex.RLock()
+	items := globalScopes[scopeModelType(reflect.TypeOf(model))]
+	globalScopesMutex.RUnlock()
+
+	if len(items) == 0 {
+		return
+	}
+
+	// Keep the conditions of the query together before restricting them
+	db.groupConditionsFrom(0)
+
+	for _, item := range items {
+		if !db.globalScopes.excluded[item.name] {
+			db.Scopes(item.scope)
+		}
+	}
+}
+
+// scopeModelType returns the struct type behind pointers and slices.
+//
+// Parameters:
+//   - typ (reflect.Type): The type of a model, a pointer to it or a slice of it.
+//
+// Returns:
+//   - reflect.Type: The model struct type.
+func scopeModelType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+
+	return typ
+}
@@ -0,0 +1,83 @@
+package db
+
+import (
+	"errors"
+	"testing"
+
+	qb "github.com/jivegroup/fluentsql"
+)
+
+func TestScopesDontLeakAfterError(t *testing.T) {
+	AddGlobalScope[queryTenant]("active", WhereScope(Condition{Field: "tenants.active", Opt: Eq, Value: true}))
+	t.Cleanup(func() {
+		RemoveGlobalScope[queryTenant]("active")
+	})
+
+	tests := []struct {
+		name string
+		fail func(db *DBModel) error
+	}{
+		{
+			name: "Find failing in the database",
+			fail: func(db *DBModel) error {
+				var tenants []queryTenant
+				_, err := db.Where("status", Eq, "a").Find(&tenants)
+
+				return err
+			},
+		},
+		{
+			name: "First failing in the database",
+			fail: func(db *DBModel) error {
+				return db.Where("status", Eq, "a").First(&queryTenant{})
+			},
+		},
+		{
+			name: "Delete without condition",
+			fail: func(db *DBModel) error {
+				return db.Delete(&queryTenant{})
+			},
+		},
+		{
+			name: "Update failing in the database",
+			fail: func(db *DBModel) error {
+				return db.Where("status", Eq, "a").Update(&queryTenant{Status: "active"})
+			},
+		},
+		{
+			name: "Paginate with invalid page",
+			fail: func(db *DBModel) error {
+				var tenants []queryTenant
+				_, err := db.Where("status", Eq, "a").Paginate(t.Context(), 0, 10, &tenants)
+
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeDB(t, new(qb.PostgreSQLDialect), fakeResult{err: errors.New("failure")})
+
+			db := Instance()
+			if err := tt.fail(db); err == nil {
+				t.Fatal("expected an error")
+			}
+
+			// Only the statement which failed in the database is executed
+			fake.statements = nil
+			fake.results = nil
+
+			// The next query of the builder has none of the conditions of the failed one
+			var posts []unionPost
+			if _, err := db.WithoutCount().Find(&posts); err != nil {
+				t.Fatal(err)
+			}
+
+			expected := []string{"SELECT * FROM posts"}
+			if len(fake.statements) != 1 || fake.statements[0] != expected[0] {
+				t.Errorf("statements = %q, expected %q", fake.statements, expected)
+			}
+		})
+	}
+}
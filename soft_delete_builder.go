@@ -0,0 +1,209 @@
+package db
+
+import (
+	"github.com/gflydev/core/errors"
+	qb "github.com/jivegroup/fluentsql"
+)
+
+// ====================================================================
+//                            Soft delete
+// ====================================================================
+
+// trashedMode represents how soft deleted rows are handled by SELECT operations.
+type trashedMode int
+
+// Trashed mode constants
+const (
+	trashedExclude trashedMode = iota // Soft deleted rows are excluded (default)
+	trashedInclude                    // Soft deleted rows are included
+	trashedOnly                       // Only soft deleted rows are returned
+)
+
+// softDeleteState represents the soft delete options of a query.
+//
+// Fields:
+//   - mode (trashedMode): How soft deleted rows are handled by SELECT operations.
+//   - force (bool): Whether Delete removes the rows instead of soft deleting them.
+//   - applied (bool): Whether the soft delete condition was already applied to the query.
+type softDeleteState struct {
+	mode    trashedMode
+	force   bool
+	applied bool
+}
+
+// WithTrashed includes soft deleted rows in the results of First, Last, Get and Find,
+// and in the rows changed by Update.
+//
+// Returns:
+//   - *DBModel: A reference to the DBModel instance for chaining.
+func (db *DBModel) WithTrashed() *DBModel {
+	db.softDelete.mode = trashedInclude
+
+	return db
+}
+
+// OnlyTrashed restricts the results of First, Last, Get and Find to soft deleted rows.
+//
+// Returns:
+//   - *DBModel: A reference to the DBModel instance for chaining.
+//
+// Example:
+//
+//	var users []User
+//	total, err := mb.Instance().OnlyTrashed().Find(&users)
+func (db *DBModel) OnlyTrashed() *DBModel {
+	db.softDelete.mode = trashedOnly
+
+	return db
+}
+
+// ForceDelete permanently removes the rows of a model having a `soft_delete` column.
+// For other models it behaves like Delete.
+//
+// Parameters:
+//   - model (any): The model defining the target table and deletion criteria.
+//
+// Returns:
+//   - error: An error object if any issues occur during the deletion process; nil otherwise.
+func (db *DBModel) ForceDelete(model any) error {
+	db.softDelete.force = true
+
+	return db.Delete(model)
+}
+
+// Restore clears the deletion time of soft deleted rows.
+// The rows are selected by the primary keys of the model and the WHERE conditions.
+//
+// Parameters:
+//   - model (any): The model defining the target table and restoring criteria.
+//     When a pointer is given, its soft delete field is cleared as well.
+//
+// Returns:
+//   - error: An error if the model has no `soft_delete` column, if there is no WHERE condition
+//     or if the update fails.
+//
+// Example:
+//
+//	user := User{ID: 1}
+//	err := mb.Instance().Restore(&user)
+//	// Executes: UPDATE users SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL
+func (db *DBModel) Restore(model any) (err error) {
+	defer db.reset()
+
+	var table *Table
+
+	if table, err = ModelData(model); err != nil {
+		return
+	}
+
+	if table.SoftDelete == nil {
+		return errors.New("Missing soft delete column for restoring operator")
+	}
+
+	var conditions []qb.Condition
+
+	// Build WHERE clause using primary columns of the table.
+	for _, primaryColumn := range table.Primaries {
+		if !primaryColumn.IsZero {
+			conditions = append(conditions, qb.Condition{
+				Field: primaryColumn.Name,
+				Opt:   Eq,
+				Value: table.Values[primaryColumn.Name],
+				AndOr: And,
+			})
+		}
+	}
+
+	// Conditions of global scopes alone must not allow restoring all rows.
+	if len(conditions) == 0 && len(db.whereStatement.Conditions) == 0 {
+		return errors.New("Missing WHERE condition for restoring operator")
+	}
+
+	// Apply global scopes of the model.
+	db.applyGlobalScopes(model)
+
+	conditions = append(conditions, db.whereStatement.Conditions...)
+
+	updateBuilder := qb.UpdateInstance().
+		Update(table.Name).
+		Set(table.SoftDelete.Name, nil).
+		WhereCondition(groupConditions(conditions)...).
+		Where(table.SoftDelete.Name, NotNull, nil)
+
+	if err = db.update(updateBuilder); err != nil {
+		return
+	}
+
+	// Keep the model in sync with the database.
+	if isStructPointer(model) {
+		err = setTimeValue(model, table.SoftDelete.Key, nil)
+	}
+
+	return
+}
+
+// softDeleteRows sets the deletion time of the rows matching the conditions.
+// Rows already soft deleted keep their original deletion time.
+//
+// Parameters:
+//   - model (any): The deleted model. When a pointer is given, its soft delete field is set as well.
+//   - table (*Table): The table of the model.
+//   - conditions ([]qb.Condition): The WHERE conditions of the deletion.
+//
+// Returns:
+//   - error: An error object if any issues occur during the update process; nil otherwise.
+func (db *DBModel) softDeleteRows(model any, table *Table, conditions []qb.Condition) error {
+	now := currentTime(table.SoftDelete.TimePrecision)
+
+	conditions = append(groupConditions(conditions), qb.Condition{
+		Field: table.SoftDelete.Name,
+		Opt:   Null,
+		AndOr: And,
+	})
+
+	sqlStr, args, _ := qb.UpdateInstance().
+		Update(table.Name).
+		Set(table.SoftDelete.Name, now).
+		WhereCondition(conditions...).
+		Sql()
+
+	if err := db.execReturning(sqlStr, args, table, conditions, false); err != nil {
+		return err
+	}
+
+	// Keep the model in sync with the database.
+	if isStructPointer(model) {
+		return setTimeValue(model, table.SoftDelete.Key, &now)
+	}
+
+	return nil
+}
+
+// applySoftDelete restricts the WHERE conditions of a SELECT or UPDATE operation according
+// to the soft delete column of the table. The WHERE conditions of the query are enclosed in
+// parentheses when they contain an OR condition. It is applied once per operation.
+//
+// Parameters:
+//   - table (*Table): The table of the query.
+func (db *DBModel) applySoftDelete(table *Table) {
+	if db.softDelete.applied || table == nil || table.SoftDelete == nil || db.softDelete.mode == trashedInclude {
+		return
+	}
+
+	db.softDelete.applied = true
+
+	opt := Null
+	if db.softDelete.mode == trashedOnly {
+		opt = NotNull
+	}
+
+	// Keep the conditions of the query together before restricting them
+	db.groupConditionsFrom(0)
+
+	// Qualify the column since JOIN clauses may bring the same column name
+	db.whereStatement.Append(qb.Condition{
+		Field: table.Name + "." + table.SoftDelete.Name,
+		Opt:   opt,
+		AndOr: And,
+	})
+}
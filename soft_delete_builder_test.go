@@ -0,0 +1,172 @@
+package db
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+	"time"
+
+	qb "github.com/jivegroup/fluentsql"
+)
+
+type softPost struct {
+	MetaData  MetaData     `db:"-" model:"table:posts"`
+	ID        int          `db:"id" model:"name:id; type:serial,primary"`
+	Title     string       `db:"title" model:"name:title"`
+	DeletedAt sql.NullTime `db:"deleted_at" model:"name:deleted_at; soft_delete"`
+}
+
+// useClock sets the time of automatic timestamps and soft deletes. The clock is restored when the test ends.
+func useClock(t *testing.T, now time.Time) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		SetClock(nil)
+	})
+
+	SetClock(func() time.Time {
+		return now
+	})
+}
+
+func TestSoftDelete(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		run          func(db *DBModel) error
+		expected     string
+		expectedArgs []driver.Value
+	}{
+		{
+			name: "Find excludes soft deleted rows",
+			run: func(db *DBModel) error {
+				var posts []softPost
+				_, err := db.WithoutCount().Find(&posts)
+
+				return err
+			},
+			expected: "SELECT * FROM posts WHERE posts.deleted_at IS NULL",
+		},
+		{
+			name: "Find with trashed rows",
+			run: func(db *DBModel) error {
+				var posts []softPost
+				_, err := db.WithTrashed().WithoutCount().Find(&posts)
+
+				return err
+			},
+			expected: "SELECT * FROM posts",
+		},
+		{
+			name: "Find only trashed rows",
+			run: func(db *DBModel) error {
+				var posts []softPost
+				_, err := db.Where("title", Eq, "a").WhereOr("title", Eq, "b").OnlyTrashed().WithoutCount().Find(&posts)
+
+				return err
+			},
+			expected:     "SELECT * FROM posts WHERE (title = $1 OR title = $2) AND posts.deleted_at IS NOT NULL",
+			expectedArgs: []driver.Value{"a", "b"},
+		},
+		{
+			name: "Delete sets the deletion time",
+			run: func(db *DBModel) error {
+				return db.Delete(&softPost{ID: 1})
+			},
+			expected:     "UPDATE posts SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL",
+			expectedArgs: []driver.Value{now, int64(1)},
+		},
+		{
+			name: "ForceDelete removes the rows",
+			run: func(db *DBModel) error {
+				return db.ForceDelete(&softPost{ID: 1})
+			},
+			expected:     "DELETE FROM posts WHERE id = $1",
+			expectedArgs: []driver.Value{int64(1)},
+		},
+		{
+			name: "Restore clears the deletion time",
+			run: func(db *DBModel) error {
+				return db.Restore(&softPost{ID: 1})
+			},
+			expected:     "UPDATE posts SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NOT NULL",
+			expectedArgs: []driver.Value{nil, int64(1)},
+		},
+		{
+			name: "Update excludes soft deleted rows",
+			run: func(db *DBModel) error {
+				return db.Update(&softPost{ID: 1, Title: "Hello"})
+			},
+			expected:     "UPDATE posts SET title = $1, deleted_at = $2 WHERE posts.deleted_at IS NULL AND id = $3",
+			expectedArgs: []driver.Value{"Hello", nil, int64(1)},
+		},
+		{
+			name: "Update with trashed rows",
+			run: func(db *DBModel) error {
+				return db.WithTrashed().Update(&softPost{ID: 1, Title: "Hello"})
+			},
+			expected:     "UPDATE posts SET title = $1, deleted_at = $2 WHERE id = $3",
+			expectedArgs: []driver.Value{"Hello", nil, int64(1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useClock(t, now)
+			fake := useFakeDB(t, new(qb.PostgreSQLDialect), fakeResult{affected: 1})
+
+			if err := tt.run(Instance()); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(fake.statements) != 1 || fake.statements[0] != tt.expected {
+				t.Fatalf("statements = %q, expected %q", fake.statements, tt.expected)
+			}
+
+			if (len(fake.args[0]) > 0 || len(tt.expectedArgs) > 0) && !reflect.DeepEqual(fake.args[0], tt.expectedArgs) {
+				t.Errorf("args = %v, expected %v", fake.args[0], tt.expectedArgs)
+			}
+		})
+	}
+}
+
+func TestSoftDeleteSyncsModel(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	useClock(t, now)
+	useFakeDB(t, new(qb.PostgreSQLDialect), fakeResult{affected: 1}, fakeResult{affected: 1})
+
+	post := softPost{ID: 1}
+	if err := Instance().Delete(&post); err != nil {
+		t.Fatal(err)
+	}
+
+	if !post.DeletedAt.Valid || !post.DeletedAt.Time.Equal(now) {
+		t.Errorf("DeletedAt = %v, expected %v", post.DeletedAt, now)
+	}
+
+	if err := Instance().Restore(&post); err != nil {
+		t.Fatal(err)
+	}
+
+	if post.DeletedAt.Valid {
+		t.Errorf("DeletedAt = %v, expected NULL", post.DeletedAt)
+	}
+}
+
+func TestRestoreWithoutCondition(t *testing.T) {
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect))
+
+	if err := Instance().Restore(&softPost{}); err == nil {
+		t.Error("Restore() expected an error")
+	}
+
+	if err := Instance().Restore(&unionPost{ID: 1}); err == nil {
+		t.Error("Restore() of a model without soft delete column expected an error")
+	}
+
+	if len(fake.statements) != 0 {
+		t.Errorf("statements = %q, expected none", fake.statements)
+	}
+}
@@ -0,0 +1,225 @@
+package db
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/gflydev/core/errors"
+	qb "github.com/jivegroup/fluentsql"
+	"github.com/jmoiron/sqlx"
+)
+
+// ====================================================================
+//                          Schemaless table
+// ====================================================================
+
+// Table sets the table of the query by its name. Without a model, the query is schemaless: rows are
+// created and updated from maps, and found into slices of maps (e.g. []map[string]any or []core.Data).
+// With a model, the name replaces the table of the model (e.g. an archive table with the same columns).
+// Schemaless queries have no primary key, so Update and Delete require WHERE conditions.
+//
+// Parameters:
+//   - name (string): The table name, optionally qualified by the schema.
+//
+// Returns:
+//   - *DBModel: A reference to the DBModel instance for chaining.
+//
+// Examples:
+//
+//	// INSERT INTO audit_events (action, user_id) VALUES ($1, $2)
+//	err := db.Table("audit_events").Create(map[string]any{"action": "login", "user_id": 1})
+//
+//	// SELECT * FROM audit_events WHERE user_id = $1
+//	var events []core.Data
+//	total, err := db.Table("audit_events").Where("user_id", mb.Eq, 1).Find(&events)
+//
+//	// UPDATE audit_events SET seen = $1 WHERE user_id = $2
+//	err = db.Table("audit_events").Where("user_id", mb.Eq, 1).Update(map[string]any{"seen": true})
+//
+//	// DELETE FROM audit_events WHERE created_at < $1
+//	err = db.Table("audit_events").Where("created_at", mb.Lesser, lastYear).Delete(nil)
+func (db *DBModel) Table(name string) *DBModel {
+	db.table = name
+
+	return db
+}
+
+// modelTable creates the table of the query from the model, or from the name given by Table.
+//
+// Returns:
+//   - *Table: The table of the query.
+//   - error: An error if neither the model nor the table name is set.
+func (db *DBModel) modelTable() (*Table, error) {
+	if db.model == nil {
+		if db.table == "" {
+			return nil, errors.New("Model must be set before building the query")
+		}
+
+		return db.schemalessTable(), nil
+	}
+
+	table, err := ModelData(db.model)
+	if err != nil {
+		return nil, err
+	}
+
+	db.applyTable(table)
+
+	return table, nil
+}
+
+// schemalessTable creates a table without columns from the name given by Table.
+//
+// Returns:
+//   - *Table: The table of the query.
+func (db *DBModel) schemalessTable() *Table {
+	table := NewTable()
+	table.Name = db.table
+
+	return table
+}
+
+// applyTable replaces the table name of a model by the name given by Table.
+//
+// Parameters:
+//   - table (*Table): The table of the model.
+func (db *DBModel) applyTable(table *Table) {
+	if db.table != "" {
+		table.Name = db.table
+	}
+}
+
+// createByTable inserts a row from a map into the table given by Table.
+//
+// Parameters:
+//   - value (any): A map where keys are column names. A value can be a plain value or an Expression.
+//
+// Returns:
+//   - error: An error object if any issues occur during the insertion; nil otherwise.
+func (db *DBModel) createByTable(value any) error {
+	table := db.schemalessTable()
+
+	mapValue := reflect.ValueOf(value)
+	for _, key := range mapValue.MapKeys() {
+		table.Values[key.String()] = db.expressionValue(mapValue.MapIndex(key).Interface())
+	}
+
+	// Insert the columns in a stable order
+	columns := slices.Sorted(maps.Keys(table.Values))
+	if len(columns) == 0 {
+		return errors.New("Invalid data :: no column to insert")
+	}
+
+	values := make([]any, len(columns))
+	for i, column := range columns {
+		table.Columns = append(table.Columns, Column{Key: column, Name: column, HasValue: true})
+		values[i] = table.Values[column]
+	}
+
+	sqlStr, args, _ := qb.InsertInstance().
+		Insert(table.Name, columns...).
+		Row(values...).
+		Sql()
+
+	// Append the clause handling unique conflicts (upsert)
+	if db.conflict != nil {
+		var err error
+		if sqlStr, err = db.conflict.apply(sqlStr, table, columns); err != nil {
+			return err
+		}
+	}
+
+	_, err := db.addRaw(sqlStr, args, nil)
+
+	return err
+}
+
+// isMapSlice checks whether the model is a pointer to a slice of maps with string keys.
+//
+// Parameters:
+//   - model (any): The model.
+//
+// Returns:
+//   - bool: true for e.g. *[]map[string]any or *[]core.Data; false otherwise.
+func isMapSlice(model any) bool {
+	typ := reflect.TypeOf(model)
+
+	return typ != nil && typ.Kind() == reflect.Ptr && typ.Elem().Kind() == reflect.Slice && isStringMap(typ.Elem().Elem())
+}
+
+// isStringMap checks whether a type is a map with string keys and any values.
+//
+// Parameters:
+//   - typ (reflect.Type): The type.
+//
+// Returns:
+//   - bool: true for e.g. map[string]any or core.Data; false otherwise.
+func isStringMap(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Map && typ.Key().Kind() == reflect.String && typ.Elem().Kind() == reflect.Interface
+}
+
+// scanMaps reads rows into a slice of maps. Values returned as bytes (e.g. by MySQL) are converted to strings,
+// except the values of binary columns (see isBinaryColumn).
+//
+// Parameters:
+//   - rows (*sqlx.Rows): The rows to read.
+//   - model (any): A pointer to a slice of maps, which is emptied before.
+//
+// Returns:
+//   - int64: The number of rows.
+//   - error: Error encountered during scanning, if any.
+func scanMaps(rows *sqlx.Rows, model any) (int64, error) {
+	sliceValue := reflect.ValueOf(model).Elem()
+	sliceValue.SetLen(0)
+	elemType := sliceValue.Type().Elem()
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return 0, err
+	}
+
+	binaryColumns := make(map[string]bool)
+	for _, columnType := range columnTypes {
+		if isBinaryColumn(columnType.DatabaseTypeName()) {
+			binaryColumns[columnType.Name()] = true
+		}
+	}
+
+	for rows.Next() {
+		row := make(map[string]any)
+		if err := rows.MapScan(row); err != nil {
+			return 0, err
+		}
+
+		for column, value := range row {
+			if b, ok := value.([]byte); ok && !binaryColumns[column] {
+				row[column] = string(b)
+			}
+		}
+
+		sliceValue.Set(reflect.Append(sliceValue, reflect.ValueOf(row).Convert(elemType)))
+	}
+
+	return int64(sliceValue.Len()), rows.Err()
+}
+
+// isBinaryColumn checks whether a column type holds binary data, which must be kept as bytes.
+// The other types returned as bytes hold text (e.g. VARCHAR, JSON, DECIMAL or DATETIME on MySQL).
+//
+// Parameters:
+//   - typeName (string): The database type name of the column (see sql.ColumnType.DatabaseTypeName).
+//
+// Returns:
+//   - bool: true for e.g. BYTEA, BLOB, VARBINARY or BIT; false otherwise.
+func isBinaryColumn(typeName string) bool {
+	typeName = strings.ToUpper(typeName)
+
+	switch typeName {
+	case "BYTEA", "BINARY", "VARBINARY", "BIT", "GEOMETRY":
+		return true
+	}
+
+	return strings.HasSuffix(typeName, "BLOB")
+}
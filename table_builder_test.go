@@ -0,0 +1,246 @@
+package db
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+
+	"github.com/gflydev/core"
+	qb "github.com/jivegroup/fluentsql"
+)
+
+func TestTableCreate(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  qb.Dialect
+		result   fakeResult
+		expected string
+	}{
+		{
+			name:     "PostgreSQL",
+			dialect:  new(qb.PostgreSQLDialect),
+			result:   fakeResult{affected: 1},
+			expected: "INSERT INTO audit_events (action, user_id) VALUES ($1, $2)",
+		},
+		{
+			name:     "MySQL",
+			dialect:  new(qb.MySQLDialect),
+			result:   fakeResult{affected: 1},
+			expected: "INSERT INTO audit_events (action, user_id) VALUES (?, ?)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeDB(t, tt.dialect, tt.result)
+
+			db := Instance()
+			if err := db.Table("audit_events").Create(map[string]any{"user_id": 1, "action": "login"}); err != nil {
+				t.Fatal(err)
+			}
+
+			// The columns are inserted in a stable order
+			if len(fake.statements) != 1 || fake.statements[0] != tt.expected {
+				t.Errorf("statements = %q, expected %q", fake.statements, tt.expected)
+			}
+
+			expectedArgs := []driver.Value{"login", int64(1)}
+			if !reflect.DeepEqual(fake.args[0], expectedArgs) {
+				t.Errorf("args = %v, expected %v", fake.args[0], expectedArgs)
+			}
+
+			if db.RowsAffected() != 1 {
+				t.Errorf("RowsAffected() = %d, expected 1", db.RowsAffected())
+			}
+		})
+	}
+}
+
+func TestTableCreateInvalid(t *testing.T) {
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect))
+
+	if err := Instance().Table("audit_events").Create(map[string]any{}); err == nil {
+		t.Error("Create() of an empty map expected an error")
+	}
+
+	if err := Instance().Create(map[string]any{"action": "login"}); err == nil {
+		t.Error("Create() of a map without Table expected an error")
+	}
+
+	if len(fake.statements) != 0 {
+		t.Errorf("statements = %q, expected none", fake.statements)
+	}
+}
+
+func TestTableFind(t *testing.T) {
+	fake := useFakeDB(t, new(qb.MySQLDialect), fakeResult{
+		columns: []string{"id", "action", "payload", "amount"},
+		types:   []string{"BIGINT", "VARCHAR", "BLOB", "DECIMAL"},
+		rows: [][]driver.Value{
+			{int64(1), []byte("login"), []byte{0xff, 0x00}, []byte("12.50")},
+			{int64(2), []byte("logout"), nil, []byte("0.00")},
+		},
+	})
+
+	events := []core.Data{{"stale": true}}
+	total, err := Instance().Table("audit_events").Where("user_id", Eq, 1).WithCount(CountNone).Find(&events)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedSQL := "SELECT * FROM audit_events WHERE user_id = ?"
+	if len(fake.statements) != 1 || fake.statements[0] != expectedSQL {
+		t.Errorf("statements = %q, expected %q", fake.statements, expectedSQL)
+	}
+
+	if total != 0 {
+		t.Errorf("total = %d, expected 0 without count", total)
+	}
+
+	// Text columns are read as strings, binary columns keep their bytes
+	expected := []core.Data{
+		{"id": int64(1), "action": "login", "payload": []byte{0xff, 0x00}, "amount": "12.50"},
+		{"id": int64(2), "action": "logout", "payload": nil, "amount": "0.00"},
+	}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("events = %v, expected %v", events, expected)
+	}
+}
+
+func TestTableFindRequiresTable(t *testing.T) {
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect))
+
+	var events []map[string]any
+	if _, err := Instance().Find(&events); err == nil {
+		t.Error("Find() of maps without Table expected an error")
+	}
+
+	if len(fake.statements) != 0 {
+		t.Errorf("statements = %q, expected none", fake.statements)
+	}
+}
+
+func TestTableUpdate(t *testing.T) {
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect), fakeResult{affected: 3})
+
+	db := Instance()
+	if err := db.Table("audit_events").Where("user_id", Eq, 1).Update(map[string]any{"seen": true}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "UPDATE audit_events SET seen = $1 WHERE user_id = $2"
+	if len(fake.statements) != 1 || fake.statements[0] != expected {
+		t.Errorf("statements = %q, expected %q", fake.statements, expected)
+	}
+
+	if db.RowsAffected() != 3 {
+		t.Errorf("RowsAffected() = %d, expected 3", db.RowsAffected())
+	}
+
+	// Schemaless tables have no primary key: the WHERE conditions are required
+	if err := db.Table("audit_events").Update(map[string]any{"seen": true}); err == nil {
+		t.Error("Update() without WHERE condition expected an error")
+	}
+
+	if len(fake.statements) != 1 {
+		t.Errorf("statements = %q, expected no statement without WHERE condition", fake.statements)
+	}
+}
+
+func TestTableDelete(t *testing.T) {
+	t.Run("nil model", func(t *testing.T) {
+		fake := useFakeDB(t, new(qb.PostgreSQLDialect), fakeResult{affected: 2})
+
+		db := Instance()
+		if err := db.Table("audit_events").Where("user_id", Eq, 1).Delete(nil); err != nil {
+			t.Fatal(err)
+		}
+
+		expected := "DELETE FROM audit_events WHERE user_id = $1"
+		if len(fake.statements) != 1 || fake.statements[0] != expected {
+			t.Errorf("statements = %q, expected %q", fake.statements, expected)
+		}
+
+		if db.RowsAffected() != 2 {
+			t.Errorf("RowsAffected() = %d, expected 2", db.RowsAffected())
+		}
+	})
+
+	t.Run("returning maps", func(t *testing.T) {
+		fake := useFakeDB(t, new(qb.PostgreSQLDialect), fakeResult{
+			columns: []string{"id", "payload"},
+			types:   []string{"INT8", "BYTEA"},
+			rows:    [][]driver.Value{{int64(1), []byte{0x01}}},
+		})
+
+		var events []map[string]any
+		if err := Instance().Table("audit_events").Where("user_id", Eq, 1).Returning().Delete(&events); err != nil {
+			t.Fatal(err)
+		}
+
+		expectedSQL := "DELETE FROM audit_events WHERE user_id = $1 RETURNING *"
+		if len(fake.statements) != 1 || fake.statements[0] != expectedSQL {
+			t.Errorf("statements = %q, expected %q", fake.statements, expectedSQL)
+		}
+
+		expected := []map[string]any{{"id": int64(1), "payload": []byte{0x01}}}
+		if !reflect.DeepEqual(events, expected) {
+			t.Errorf("events = %v, expected %v", events, expected)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		fake := useFakeDB(t, new(qb.PostgreSQLDialect))
+
+		if err := Instance().Table("audit_events").Delete(nil); err == nil {
+			t.Error("Delete() without WHERE condition expected an error")
+		}
+
+		if err := Instance().Where("user_id", Eq, 1).Delete(nil); err == nil {
+			t.Error("Delete() of nil without Table expected an error")
+		}
+
+		if len(fake.statements) != 0 {
+			t.Errorf("statements = %q, expected none", fake.statements)
+		}
+	})
+}
+
+func TestTableWithModel(t *testing.T) {
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect), fakeResult{affected: 1})
+
+	// The name replaces the table of the model
+	if err := Instance().Table("users_archive").Delete(&createUser{ID: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "DELETE FROM users_archive WHERE id = $1"
+	if len(fake.statements) != 1 || fake.statements[0] != expected {
+		t.Errorf("statements = %q, expected %q", fake.statements, expected)
+	}
+}
+
+func TestIsBinaryColumn(t *testing.T) {
+	tests := []struct {
+		typeName string
+		expected bool
+	}{
+		{"BYTEA", true},
+		{"bytea", true},
+		{"BLOB", true},
+		{"MEDIUMBLOB", true},
+		{"VARBINARY", true},
+		{"BIT", true},
+		{"VARCHAR", false},
+		{"TEXT", false},
+		{"JSON", false},
+		{"DECIMAL", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if actual := isBinaryColumn(tt.typeName); actual != tt.expected {
+			t.Errorf("isBinaryColumn(%q) = %v, expected %v", tt.typeName, actual, tt.expected)
+		}
+	}
+}
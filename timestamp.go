@@ -0,0 +1,170 @@
+package db
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ====================================================================
+//                       Automatic timestamps
+// ====================================================================
+
+var (
+	// clock returns the current time used by automatic timestamps and soft deletes.
+	clock = time.Now
+
+	// timePrecision is the default precision of automatic timestamps.
+	// PostgreSQL and MySQL (DATETIME(6)) store microseconds.
+	timePrecision = time.Microsecond
+
+	// clockMutex guards clock and timePrecision.
+	clockMutex sync.RWMutex
+)
+
+// SetClock sets the function returning the current time used by automatic timestamps
+// (`autoCreateTime`, `autoUpdateTime`) and soft deletes. Useful to get deterministic tests.
+//
+// Parameters:
+//   - fn (func() time.Time): The clock function. Nil restores time.Now.
+//
+// Example:
+//
+//	fixed := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+//	mb.SetClock(func() time.Time { return fixed })
+//	defer mb.SetClock(nil)
+func SetClock(fn func() time.Time) {
+	if fn == nil {
+		fn = time.Now
+	}
+
+	clockMutex.Lock()
+	defer clockMutex.Unlock()
+
+	clock = fn
+}
+
+// SetTimePrecision sets the default precision of automatic timestamps. Timestamps are truncated
+// to this precision so that the model holds the same value as the database. Default is microsecond.
+//
+// Parameters:
+//   - precision (time.Duration): The precision (e.g. time.Millisecond). Values <= 0 keep nanoseconds.
+func SetTimePrecision(precision time.Duration) {
+	clockMutex.Lock()
+	defer clockMutex.Unlock()
+
+	timePrecision = precision
+}
+
+// currentTime returns the current time of the clock truncated to the given precision.
+// The monotonic clock reading is stripped.
+//
+// Parameters:
+//   - precision (time.Duration): The precision. Zero uses the default precision.
+//
+// Returns:
+//   - time.Time: The current time.
+func currentTime(precision time.Duration) time.Time {
+	clockMutex.RLock()
+	now := clock()
+	if precision == 0 {
+		precision = timePrecision
+	}
+	clockMutex.RUnlock()
+
+	if precision <= 0 {
+		return now.Round(0)
+	}
+
+	return now.Truncate(precision)
+}
+
+// parseTimePrecision converts the value of an automatic timestamp tag to a precision.
+//
+// Parameters:
+//   - slice ([]string): The tag values (e.g. []string{"milli"}).
+//
+// Returns:
+//   - time.Duration: The precision. Zero for the default precision.
+func parseTimePrecision(slice []string) time.Duration {
+	if len(slice) == 0 {
+		return 0
+	}
+
+	switch strings.ToLower(slice[0]) {
+	case "nano":
+		return time.Nanosecond
+	case "micro":
+		return time.Microsecond
+	case "milli":
+		return time.Millisecond
+	case "second", "sec":
+		return time.Second
+	}
+
+	return 0
+}
+
+// setAutoTimes assigns the automatic timestamps of the table and of the model.
+// On creation, `autoCreateTime` and `autoUpdateTime` columns are set when they are zero.
+// On update, `autoUpdateTime` columns are always set.
+// Integer columns hold a Unix timestamp in the unit of the column precision (default second).
+//
+// Parameters:
+//   - model (any): The model. When a pointer is given, its fields are set as well.
+//   - creating (bool): Whether the model is being created.
+//
+// Returns:
+//   - error: An error if a field can't hold a timestamp.
+func (tbl *Table) setAutoTimes(model any, creating bool) error {
+	value := reflect.Indirect(reflect.ValueOf(model))
+
+	for i := range tbl.Columns {
+		column := &tbl.Columns[i]
+
+		isSet := (creating && (column.AutoCreateTime || column.AutoUpdateTime) && column.IsZero) ||
+			(!creating && column.AutoUpdateTime)
+		if !isSet {
+			continue
+		}
+
+		field := value.FieldByName(column.Key)
+		if !field.IsValid() {
+			continue
+		}
+
+		var val any
+
+		switch field.Kind() {
+		case reflect.Int, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+			// Unix timestamp in the unit of the column precision
+			unit := column.TimePrecision
+			if unit == 0 {
+				unit = time.Second
+			}
+			val = currentTime(unit).UnixNano() / int64(unit)
+
+			if isStructPointer(model) {
+				if err := setValue(model, column.Key, val); err != nil {
+					return err
+				}
+			}
+		default:
+			now := currentTime(column.TimePrecision)
+			val = now
+
+			if isStructPointer(model) {
+				if err := setTimeValue(model, column.Key, &now); err != nil {
+					return err
+				}
+			}
+		}
+
+		tbl.Values[column.Name] = val
+		column.HasValue = true
+		column.IsZero = false
+	}
+
+	return nil
+}
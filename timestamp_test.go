@@ -0,0 +1,172 @@
+package db
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+	"time"
+
+	qb "github.com/jivegroup/fluentsql"
+)
+
+type timestampPost struct {
+	MetaData   MetaData   `db:"-" model:"table:posts"`
+	ID         int        `db:"id" model:"name:id; type:serial,primary"`
+	Title      string     `db:"title" model:"name:title"`
+	CreatedAt  time.Time  `db:"created_at" model:"name:created_at; autoCreateTime"`
+	UpdatedAt  *time.Time `db:"updated_at" model:"name:updated_at; autoUpdateTime:milli"`
+	CreatedSec int64      `db:"created_sec" model:"name:created_sec; autoCreateTime"`
+	UpdatedMs  int64      `db:"updated_ms" model:"name:updated_ms; autoUpdateTime:milli"`
+}
+
+// testNow has nanoseconds, so that every precision truncates it.
+var testNow = time.Date(2026, 1, 2, 3, 4, 5, 123456789, time.UTC)
+
+// useTimePrecision sets the default precision of automatic timestamps. The precision is restored when the test ends.
+func useTimePrecision(t *testing.T, precision time.Duration) {
+	t.Helper()
+
+	clockMutex.RLock()
+	previousPrecision := timePrecision
+	clockMutex.RUnlock()
+
+	t.Cleanup(func() {
+		SetTimePrecision(previousPrecision)
+	})
+
+	SetTimePrecision(precision)
+}
+
+func TestReadTags(t *testing.T) {
+	tests := []struct {
+		tags     string
+		expected map[string][]string
+	}{
+		{tags: "", expected: map[string][]string{TYPE: {"BOOLEAN"}}},
+		{tags: "name:id; type:serial,primary", expected: map[string][]string{"name": {"id"}, "type": {"serial", "primary"}}},
+		{tags: "name:deleted_at; soft_delete", expected: map[string][]string{"name": {"deleted_at"}, "soft_delete": {}}},
+		{tags: "name:created_at; autoCreateTime;", expected: map[string][]string{"name": {"created_at"}, "autoCreateTime": {}}},
+		{tags: "autoUpdateTime:milli; version", expected: map[string][]string{"autoUpdateTime": {"milli"}, "version": {}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tags, func(t *testing.T) {
+			if result := readTags(tt.tags); !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("readTags(%q) = %v, expected %v", tt.tags, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCurrentTime(t *testing.T) {
+	tests := []struct {
+		name             string
+		defaultPrecision time.Duration
+		precision        time.Duration
+		expected         time.Time
+	}{
+		{
+			name:             "default precision",
+			defaultPrecision: time.Microsecond,
+			expected:         time.Date(2026, 1, 2, 3, 4, 5, 123456000, time.UTC),
+		},
+		{
+			name:             "other default precision",
+			defaultPrecision: time.Millisecond,
+			expected:         time.Date(2026, 1, 2, 3, 4, 5, 123000000, time.UTC),
+		},
+		{
+			name:             "nanoseconds kept",
+			defaultPrecision: 0,
+			expected:         testNow,
+		},
+		{
+			name:             "column precision",
+			defaultPrecision: time.Microsecond,
+			precision:        time.Second,
+			expected:         time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useClock(t, testNow)
+			useTimePrecision(t, tt.defaultPrecision)
+
+			if result := currentTime(tt.precision); !result.Equal(tt.expected) {
+				t.Errorf("currentTime(%v) = %v, expected %v", tt.precision, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSetAutoTimes(t *testing.T) {
+	useClock(t, testNow)
+	useTimePrecision(t, time.Microsecond)
+
+	created := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Creation: zero timestamps are set, the others are kept
+	post := timestampPost{Title: "Hello", CreatedAt: created}
+	table, err := CreateData(&post)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedUpdated := time.Date(2026, 1, 2, 3, 4, 5, 123000000, time.UTC)
+	if !post.CreatedAt.Equal(created) || post.UpdatedAt == nil || !post.UpdatedAt.Equal(expectedUpdated) {
+		t.Errorf("CreatedAt, UpdatedAt = %v, %v, expected %v, %v", post.CreatedAt, post.UpdatedAt, created, expectedUpdated)
+	}
+
+	// Integer columns hold a Unix timestamp in the unit of their precision
+	if post.CreatedSec != testNow.Unix() || post.UpdatedMs != testNow.UnixMilli() {
+		t.Errorf("CreatedSec, UpdatedMs = %d, %d, expected %d, %d",
+			post.CreatedSec, post.UpdatedMs, testNow.Unix(), testNow.UnixMilli())
+	}
+
+	if table.Values["created_sec"] != testNow.Unix() || table.Values["updated_ms"] != testNow.UnixMilli() {
+		t.Errorf("Values = %v, expected the Unix timestamps", table.Values)
+	}
+
+	// Update: only `autoUpdateTime` columns are set
+	later := testNow.Add(time.Hour)
+	useClock(t, later)
+
+	table, err = ModelData(&post)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = table.setAutoTimes(&post, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if !post.CreatedAt.Equal(created) || post.CreatedSec != testNow.Unix() {
+		t.Errorf("CreatedAt, CreatedSec = %v, %d, expected them unchanged", post.CreatedAt, post.CreatedSec)
+	}
+
+	if !post.UpdatedAt.Equal(later.Truncate(time.Millisecond)) || post.UpdatedMs != later.UnixMilli() {
+		t.Errorf("UpdatedAt, UpdatedMs = %v, %d, expected %v", post.UpdatedAt, post.UpdatedMs, later)
+	}
+}
+
+func TestUpdateSetsAutoUpdateTime(t *testing.T) {
+	useClock(t, testNow)
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect), fakeResult{affected: 1})
+
+	created := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	post := timestampPost{ID: 1, Title: "Hello", CreatedAt: created, CreatedSec: created.Unix()}
+	if err := Instance().Update(&post); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "UPDATE posts SET title = $1, updated_at = $2, updated_ms = $3 WHERE id = $4"
+	if len(fake.statements) != 1 || fake.statements[0] != expected {
+		t.Fatalf("statements = %q, expected %q", fake.statements, expected)
+	}
+
+	expectedArgs := []driver.Value{"Hello", testNow.Truncate(time.Millisecond), testNow.UnixMilli(), int64(1)}
+	if !reflect.DeepEqual(fake.args[0], expectedArgs) {
+		t.Errorf("args = %v, expected %v", fake.args[0], expectedArgs)
+	}
+}
@@ -0,0 +1,273 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"github.com/gflydev/core/errors"
+	qb "github.com/jivegroup/fluentsql"
+	"strings"
+)
+
+// ====================================================================
+//                         Set operators
+// ====================================================================
+
+// UnionType represents the set operator used to combine two SELECT statements.
+type UnionType int
+
+// Set operator constants
+const (
+	UnionDistinct UnionType = iota // UNION (duplicate rows removed)
+	UnionAllRows                   // UNION ALL (duplicate rows kept)
+	IntersectRows                  // INTERSECT
+	ExceptRows                     // EXCEPT
+)
+
+// UnionItem represents a single query combined with the main query by a set operator.
+//
+// Fields:
+//   - Type (UnionType): The set operator placed before the query.
+//   - Query (*DBModel): The query to combine.
+type UnionItem struct {
+	Type  UnionType
+	Query *DBModel
+}
+
+// opt returns the SQL keyword of the set operator.
+//
+// Returns:
+//   - string: The SQL set operator ("UNION", "UNION ALL", "INTERSECT" or "EXCEPT").
+func (u *UnionItem) opt() string {
+	var sign string
+
+	switch u.Type {
+	case UnionDistinct:
+		sign = "UNION"
+	case UnionAllRows:
+		sign = "UNION ALL"
+	case IntersectRows:
+		sign = "INTERSECT"
+	case ExceptRows:
+		sign = "EXCEPT"
+	}
+
+	return sign
+}
+
+// Union represents the set operations of a query.
+//
+// Fields:
+//   - Items ([]UnionItem): The queries combined with the main query, in order.
+type Union struct {
+	Items []UnionItem
+}
+
+// Append adds a query combined by the given set operator.
+//
+// Parameters:
+//   - unionType (UnionType): The set operator.
+//   - query (*DBModel): The query to combine.
+func (u *Union) Append(unionType UnionType, query *DBModel) {
+	u.Items = append(u.Items, UnionItem{
+		Type:  unionType,
+		Query: query,
+	})
+}
+
+// ====================================================================
+//                       DB Model set operators
+// ====================================================================
+
+// Union combines the query with another one using UNION (duplicate rows removed).
+// Every query must select the same number of columns with compatible types.
+// ORDER BY, LIMIT and FETCH of the main query are applied to the combined result,
+// while those of the combined queries only apply to their own rows.
+// A combined query can't have set operations itself (ToQueryBuilder rejects it), and row
+// locking (LockForUpdate, SharedLock) can't be used with set operations: Find returns an error.
+//
+// Parameters:
+//   - query (*DBModel): The query to combine.
+//
+// Returns:
+//   - *DBModel: A reference to the DBModel instance for chaining.
+//
+// Example:
+//
+//	posts := mb.Instance().Model(&Post{}).Select("id", "title", "created_at")
+//	comments := mb.Instance().Model(&Comment{}).Select("id", "body AS title", "created_at")
+//
+//	var activities []Activity
+//	total, err := mb.Instance().Model(&Event{}).
+//	    Select("id", "name AS title", "created_at").
+//	    Union(posts).
+//	    UnionAll(comments).
+//	    OrderBy("created_at", mb.Desc).
+//	    Limit(20, 0).
+//	    Find(&activities)
+func (db *DBModel) Union(query *DBModel) *DBModel {
+	db.unionStatement.Append(UnionDistinct, query)
+
+	return db
+}
+
+// UnionAll combines the query with another one using UNION ALL (duplicate rows kept).
+//
+// Parameters:
+//   - query (*DBModel): The query to combine.
+//
+// Returns:
+//   - *DBModel: A reference to the DBModel instance for chaining.
+func (db *DBModel) UnionAll(query *DBModel) *DBModel {
+	db.unionStatement.Append(UnionAllRows, query)
+
+	return db
+}
+
+// Intersect combines the query with another one using INTERSECT.
+// MySQL supports INTERSECT since version 8.0.31.
+//
+// Parameters:
+//   - query (*DBModel): The query to combine.
+//
+// Returns:
+//   - *DBModel: A reference to the DBModel instance for chaining.
+func (db *DBModel) Intersect(query *DBModel) *DBModel {
+	db.unionStatement.Append(IntersectRows, query)
+
+	return db
+}
+
+// Except combines the query with another one using EXCEPT.
+// MySQL supports EXCEPT since version 8.0.31.
+//
+// Parameters:
+//   - query (*DBModel): The query to combine.
+//
+// Returns:
+//   - *DBModel: A reference to the DBModel instance for chaining.
+func (db *DBModel) Except(query *DBModel) *DBModel {
+	db.unionStatement.Append(ExceptRows, query)
+
+	return db
+}
+
+// ====================================================================
+//                         Union processing
+// ====================================================================
+
+// unionSql builds the SQL combining the main query with the set operations.
+// Each query is enclosed in parentheses so that its own ORDER BY and LIMIT stay local.
+// The arguments are numbered through all queries to keep PostgreSQL placeholders in order.
+//
+// Parameters:
+//   - q (*qb.QueryBuilder): The main query without ORDER BY, LIMIT and FETCH clauses.
+//   - withPagination (bool): Whether the main query's LIMIT and FETCH are appended.
+//
+// Returns:
+//   - string: The combined SQL query.
+//   - []any: The arguments of the combined query.
+//   - error: An error if row locking is used or one of the combined queries cannot be built.
+func (db *DBModel) unionSql(q *qb.QueryBuilder, withPagination bool) (string, []any, error) {
+	// Databases don't lock the rows of a combined result
+	if db.lockStatement.Strength != LockNone {
+		return "", nil, errors.New("Row locking is not supported with set operations")
+	}
+
+	var args []any
+	var sqlPart string
+	var queryParts []string
+
+	sqlPart, args, _ = q.StringArgs(args)
+	queryParts = append(queryParts, fmt.Sprintf("(%s)", sqlPart))
+
+	for _, unionItem := range db.unionStatement.Items {
+		if unionItem.Query == nil {
+			return "", nil, errors.New("Missing query for %s operator", unionItem.opt())
+		}
+
+		if unionItem.Query.lockStatement.Strength != LockNone {
+			return "", nil, errors.New("Row locking is not supported with set operations")
+		}
+
+		// Build a copy, so that the scopes applied to it don't change the combined query
+		query := *unionItem.Query
+
+		queryBuilder, err := query.ToQueryBuilder()
+		if err != nil {
+			return "", nil, err
+		}
+
+		// Bind the expressions of the combined query with those of the main query
+		for key, e := range query.expressions {
+			if db.expressions == nil {
+				db.expressions = make(map[string]Expression)
+			}
+
+			db.expressions[key] = e
+		}
+
+		sqlPart, args, _ = queryBuilder.StringArgs(args)
+		queryParts = append(queryParts, unionItem.opt(), fmt.Sprintf("(%s)", sqlPart))
+	}
+
+	// Append ORDER BY clause applied to the combined result
+	sqlPart, args = db.orderByStatement.StringArgs(args)
+	if sqlPart != "" {
+		queryParts = append(queryParts, sqlPart)
+	}
+
+	if withPagination {
+		// Append LIMIT clause applied to the combined result
+		sqlPart, args = db.limitStatement.StringArgs(args)
+		if sqlPart != "" {
+			queryParts = append(queryParts, sqlPart)
+		}
+
+		// Append FETCH clause applied to the combined result
+		sqlPart, args = db.fetchStatement.StringArgs(args)
+		if sqlPart != "" {
+			queryParts = append(queryParts, sqlPart)
+		}
+	}
+
+	return strings.Join(queryParts, " "), args, nil
+}
+
+// findUnion queries the rows of the combined queries and counts the total of the combined result.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the queries (cancellation, deadline).
+//   - q (*qb.QueryBuilder): The main query without ORDER BY, LIMIT and FETCH clauses.
+//   - model (any): A pointer to the slice where the retrieved rows will be stored.
+//
+// Returns:
+//   - total (int): The total number of rows of the combined result, ignoring LIMIT and FETCH.
+//   - err (error): An error object if any issues occur during the retrieval process; nil otherwise.
+func (db *DBModel) findUnion(ctx context.Context, q *qb.QueryBuilder, model any) (total int, err error) {
+	var sqlStr string
+	var args []any
+
+	// Query the combined rows
+	if sqlStr, args, err = db.unionSql(q, true); err != nil {
+		return
+	}
+
+	if err = db.queryRawContext(ctx, sqlStr, args, model); err != nil {
+		return
+	}
+
+	if db.countStrategy == CountNone {
+		return
+	}
+
+	// Query COUNT over the combined rows without pagination
+	if sqlStr, args, err = db.unionSql(q, false); err != nil {
+		return
+	}
+
+	sqlCount := fmt.Sprintf("SELECT COUNT(*) AS total FROM (%s) _result_out_", sqlStr)
+
+	err = db.getRawContext(ctx, sqlCount, args, &total)
+
+	return
+}
@@ -0,0 +1,114 @@
+package db
+
+import (
+	"testing"
+
+	qb "github.com/jivegroup/fluentsql"
+)
+
+type unionEvent struct {
+	MetaData MetaData `db:"-" model:"table:events"`
+	ID       int      `db:"id" model:"name:id; type:serial,primary"`
+	Name     string   `db:"name" model:"name:name"`
+}
+
+type unionPost struct {
+	MetaData MetaData `db:"-" model:"table:posts"`
+	ID       int      `db:"id" model:"name:id; type:serial,primary"`
+	Title    string   `db:"title" model:"name:title"`
+}
+
+func TestUnionFind(t *testing.T) {
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect))
+
+	posts := Instance().Model(&unionPost{}).Select("id", "title AS name").Where("title", Like, "%go%")
+
+	var events []unionEvent
+	_, err := Instance().Model(&unionEvent{}).Select("id", "name").Where("id", Greater, 10).
+		UnionAll(posts).
+		OrderBy("id", Desc).
+		Limit(5, 0).
+		WithCount(CountNone).
+		Find(&events)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "(SELECT id, name FROM events WHERE id > $1) UNION ALL " +
+		"(SELECT id, title AS name FROM posts WHERE title LIKE $2) ORDER BY id DESC LIMIT $3 OFFSET $4"
+	if len(fake.statements) != 1 || fake.statements[0] != expected {
+		t.Errorf("statements = %q, expected %q", fake.statements, expected)
+	}
+}
+
+func TestUnionCombinedQueryUnchanged(t *testing.T) {
+	AddGlobalScope[unionPost]("published", WhereScope(Condition{Field: "published", Opt: Eq, Value: true}))
+	t.Cleanup(func() {
+		RemoveGlobalScope[unionPost]("published")
+	})
+
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect))
+
+	posts := Instance().Model(&unionPost{}).Select("id", Expr("COALESCE(title, ?) AS name", "untitled"))
+
+	// The combined query is built from a copy: its scopes and expressions are applied on every use
+	for range 2 {
+		var events []unionEvent
+		_, err := Instance().Model(&unionEvent{}).Select("id", "name").Where("id", Greater, 10).
+			Union(posts).
+			WithCount(CountNone).
+			Find(&events)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := "(SELECT id, name FROM events WHERE id > $1) UNION " +
+		"(SELECT id, COALESCE(title, $2) AS name FROM posts WHERE published = $3)"
+	if len(fake.statements) != 2 || fake.statements[0] != expected || fake.statements[1] != expected {
+		t.Errorf("statements = %q, expected %q twice", fake.statements, expected)
+	}
+}
+
+func TestUnionErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		query func() *DBModel
+	}{
+		{
+			name: "locked main query",
+			query: func() *DBModel {
+				return Instance().Model(&unionEvent{}).LockForUpdate().Union(Instance().Model(&unionPost{}))
+			},
+		},
+		{
+			name: "locked combined query",
+			query: func() *DBModel {
+				return Instance().Model(&unionEvent{}).Union(Instance().Model(&unionPost{}).SharedLock())
+			},
+		},
+		{
+			name: "nested set operations",
+			query: func() *DBModel {
+				nested := Instance().Model(&unionPost{}).Union(Instance().Model(&unionPost{}))
+
+				return Instance().Model(&unionEvent{}).Union(nested)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeDB(t, new(qb.PostgreSQLDialect))
+
+			var events []unionEvent
+			if _, err := tt.query().Find(&events); err == nil {
+				t.Error("Find() expected an error")
+			}
+
+			if len(fake.statements) != 0 {
+				t.Errorf("statements = %q, expected none", fake.statements)
+			}
+		})
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"github.com/gflydev/core/errors"
 	"github.com/gflydev/core/log"
 	qb "github.com/jivegroup/fluentsql"
+	"maps"
 	"reflect"
+	"slices"
 )
 
 // Update modifies data for a table using a model of type Struct or *Struct.
@@ -15,12 +17,17 @@ import (
 // Returns:
 //   - error: Returns an error if the update process fails.
 func (db *DBModel) Update(model any) (err error) {
+	// Reset fluent model builder, also when an error stops the update
+	defer db.reset()
+
 	typ := reflect.TypeOf(model)
 
 	switch {
 	case db.raw.sqlStr != "":
 		// Execute raw SQL query if provided
-		err = db.execRaw(db.raw.sqlStr, db.raw.args)
+		if err = db.execRaw(db.raw.sqlStr, db.raw.args); err == nil {
+			err = db.strictRowsAffected()
+		}
 	case typ.Kind() == reflect.Map:
 		// Update using map data
 		err = db.updateByMap(model)
@@ -29,9 +36,6 @@ func (db *DBModel) Update(model any) (err error) {
 		err = db.updateByStruct(model)
 	}
 
-	// Reset fluent model builder
-	db.reset()
-
 	return
 }
 
@@ -45,6 +49,18 @@ func (db *DBModel) Update(model any) (err error) {
 func (db *DBModel) updateByMap(value any) error {
 	var err error
 
+	// Schemaless update of the columns of Table
+	if db.model == nil && db.table != "" {
+		mapValue := reflect.ValueOf(value)
+		for _, key := range mapValue.MapKeys() {
+			db.Set(key.String(), mapValue.MapIndex(key).Interface())
+		}
+
+		_, err = db.updateColumns()
+
+		return err
+	}
+
 	if db.model == nil {
 		return errors.New("Missing model for map value")
 	}
@@ -93,39 +109,55 @@ func (db *DBModel) updateByStruct(model any) (err error) {
 	if table, err = ModelData(model); err != nil {
 		return
 	}
+	db.applyTable(table)
+
+	// Values of a model tracking its changes (nil when the model was not loaded).
+	original := originalOf(model)
+
+	// Nothing to write when no column changed since the model was loaded.
+	if original != nil && len(db.setStatement.Items) == 0 && !table.hasChanges(original) {
+		db.rowsAffected = 0
+		return
+	}
+
+	// Set automatic update timestamps.
+	if err = table.setAutoTimes(model, false); err != nil {
+		return
+	}
 
 	// Initialize the Update query builder for the target database table.
 	updateBuilder := qb.UpdateInstance().Update(table.Name)
 
-	// Build WHERE conditions from pre-defined conditions in 'whereStatement'.
-	for _, condition := range db.whereStatement.Conditions {
-		switch {
-		case len(condition.Group) > 0:
-			// Append grouped conditions using a builder function.
-			updateBuilder.WhereGroup(func(whereBuilder qb.WhereBuilder) *qb.WhereBuilder {
-				whereBuilder.WhereCondition(condition.Group...)
-				return &whereBuilder
-			})
-			hasCondition = true
-		case condition.AndOr == And:
-			// Add an AND clause to the WHERE condition.
-			updateBuilder.Where(condition.Field, condition.Opt, condition.Value)
-			hasCondition = true
-		case condition.AndOr == Or:
-			// Add an OR clause to the WHERE condition.
-			updateBuilder.WhereOr(condition.Field, condition.Opt, condition.Value)
-			hasCondition = true
-		}
+	// Conditions of global scopes alone must not replace the primary key condition.
+	hasCondition = len(db.whereStatement.Conditions) > 0
+
+	// Apply global scopes of the model and skip soft deleted rows.
+	db.applyGlobalScopes(model)
+	db.applySoftDelete(table)
+
+	// Check the version read from the database (optimistic locking) unless it is explicitly set.
+	versionCondition, checkVersion := table.versionCondition()
+	checkVersion = checkVersion && !db.hasSetItem(table.Version.Name)
+	if checkVersion {
+		// Keep the conditions together before restricting them by version.
+		db.groupConditionsFrom(0)
 	}
 
+	// Build WHERE conditions from pre-defined conditions in 'whereStatement'.
+	// Grouped, AND and OR conditions are kept as they are.
+	conditions := slices.Clone(db.whereStatement.Conditions)
+
 	// Build WHERE condition using primary key column values if no other condition exists.
 	if !hasCondition {
 		for _, column := range table.Columns {
 			if column.Primary {
 				// Use primary key column value for the WHERE condition.
-				value := table.Values[column.Name]
-
-				updateBuilder.Where(column.Name, Eq, value)
+				conditions = append(conditions, qb.Condition{
+					Field: column.Name,
+					Opt:   Eq,
+					Value: table.Values[column.Name],
+					AndOr: And,
+				})
 				hasCondition = true
 			}
 		}
@@ -137,10 +169,27 @@ func (db *DBModel) updateByStruct(model any) (err error) {
 		return
 	}
 
+	// Build WHERE condition on the version.
+	if checkVersion {
+		conditions = append(conditions, versionCondition)
+	}
+
+	updateBuilder.WhereCondition(conditions...)
+
 	// Iterate through the table's columns and add SET clauses for valid data fields.
 	for _, column := range table.Columns {
-		// Skip processing for columns that are not valid data fields or are primary keys.
-		if column.isNotData() || column.Primary {
+		// Skip processing for columns that are not valid data fields, primary keys, creation timestamps or versions.
+		if column.isNotData() || column.Primary || column.AutoCreateTime || column.Version {
+			continue
+		}
+
+		// Skip columns having an explicit SET item.
+		if db.hasSetItem(column.Name) {
+			continue
+		}
+
+		// Skip columns not changed since the model was loaded (dirty tracking).
+		if !column.AutoUpdateTime && !table.isChanged(column, original) {
 			continue
 		}
 
@@ -148,8 +197,190 @@ func (db *DBModel) updateByStruct(model any) (err error) {
 		updateBuilder.Set(column.Name, table.Values[column.Name])
 	}
 
+	// Append explicit SET items (plain values or expressions).
+	for _, item := range db.setStatement.Items {
+		updateBuilder.Set(item.Field, item.Value)
+	}
+
+	// Increment the version unless it is explicitly set.
+	if table.Version != nil && !db.hasSetItem(table.Version.Name) {
+		updateBuilder.Set(table.Version.Name, ValueField(table.Version.Name+" + 1"))
+	}
+
+	// Scan the updated row back into the model (Returning).
+	if isStructPointer(model) {
+		db.returning.dest = model
+	}
+
 	// Execute the update operation using the constructed query builder.
-	err = db.update(updateBuilder)
+	sqlStr, args, _ := updateBuilder.Sql()
+	if err = db.execReturning(sqlStr, args, table, conditions, false); err != nil {
+		return
+	}
+
+	// No row matches the version: the model was modified or deleted by someone else.
+	if checkVersion {
+		if db.rowsAffected == 0 {
+			err = table.staleObjectError()
+			return
+		}
+
+		// The returned row already holds the new version.
+		if !isStructPointer(model) || !db.returns(table.Version.Name) {
+			table.incrementVersion(model)
+		}
+	}
+
+	// No row matches the conditions in strict mode.
+	if err = db.strictRowsAffected(); err != nil {
+		return
+	}
+
+	// Keep the written values of models tracking their changes.
+	snapshotModels(model)
 
 	return
 }
+
+// hasSetItem checks whether an explicit SET item exists for the column.
+//
+// Parameters:
+//   - column (string): The column name.
+//
+// Returns:
+//   - bool: true if the column is assigned by Set(); false otherwise.
+func (db *DBModel) hasSetItem(column string) bool {
+	for _, item := range db.setStatement.Items {
+		if item.Field == column {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UpdateColumns updates exactly the given columns of the rows matching the WHERE conditions, without loading
+// the models. Without WHERE conditions, the rows are matched by the primary keys of the model.
+// Unlike Update, the values are not copied into the model, and the `autoUpdateTime` columns and the
+// `version` column of optimistic locking are not changed: set them explicitly when needed.
+// Soft deleted rows are not updated, unless WithTrashed or OnlyTrashed is used.
+//
+// Parameters:
+//   - values (map[string]any): The column names and their new values. A value can be a plain value or an Expression.
+//
+// Returns:
+//   - int64: The number of affected rows.
+//   - error: Returns an error if no value is given, the model is missing, no WHERE condition exists or the update fails.
+//
+// Example:
+//
+//	count, err := db.Model(&User{}).
+//	    Where("status", mb.Eq, "pending").
+//	    UpdateColumns(map[string]any{"status": "active", "activated_at": time.Now()})
+func (db *DBModel) UpdateColumns(values map[string]any) (int64, error) {
+	if len(values) == 0 {
+		db.reset()
+
+		return 0, errors.New("Invalid data :: no column to update")
+	}
+
+	for _, column := range slices.Sorted(maps.Keys(values)) {
+		db.Set(column, values[column])
+	}
+
+	return db.updateColumns()
+}
+
+// Increment atomically increases a column of the rows matching the WHERE conditions, without loading the models.
+// Without WHERE conditions, the rows are matched by the primary keys of the model. Like UpdateColumns, it
+// doesn't change automatic timestamps and versions, and skips soft deleted rows.
+//
+// Parameters:
+//   - column (string): The numeric column to increase.
+//   - amount (any): The amount added to the column.
+//
+// Returns:
+//   - int64: The number of affected rows.
+//   - error: Returns an error if the model is missing, no WHERE condition exists or the update fails.
+//
+// Example:
+//
+//	count, err := db.Model(&user).Increment("login_count", 1)
+func (db *DBModel) Increment(column string, amount any) (int64, error) {
+	db.Set(column, Expr(column+" + ?", amount))
+
+	return db.updateColumns()
+}
+
+// Decrement atomically decreases a column of the rows matching the WHERE conditions, without loading the models.
+// Without WHERE conditions, the rows are matched by the primary keys of the model. Like UpdateColumns, it
+// doesn't change automatic timestamps and versions, and skips soft deleted rows.
+//
+// Parameters:
+//   - column (string): The numeric column to decrease.
+//   - amount (any): The amount subtracted from the column.
+//
+// Returns:
+//   - int64: The number of affected rows.
+//   - error: Returns an error if the model is missing, no WHERE condition exists or the update fails.
+//
+// Example:
+//
+//	count, err := db.Model(&Product{}).Where("id", mb.Eq, 10).Decrement("stock", 2)
+func (db *DBModel) Decrement(column string, amount any) (int64, error) {
+	db.Set(column, Expr(column+" - ?", amount))
+
+	return db.updateColumns()
+}
+
+// updateColumns updates the table of the model with the explicit SET items only.
+//
+// Returns:
+//   - int64: The number of affected rows.
+//   - error: Returns an error if the model is missing, no WHERE condition exists or the update fails.
+func (db *DBModel) updateColumns() (rowsAffected int64, err error) {
+	// Reset fluent model builder
+	defer db.reset()
+
+	table, err := db.modelTable()
+	if err != nil {
+		return 0, err
+	}
+
+	// Conditions of global scopes alone must not allow updating all rows.
+	hasCondition := len(db.whereStatement.Conditions) > 0
+
+	// Match the rows by the primary keys of the model when no condition is given.
+	if !hasCondition {
+		for _, column := range table.Primaries {
+			if column.IsZero {
+				continue
+			}
+
+			db.Where(column.Name, Eq, table.Values[column.Name])
+			hasCondition = true
+		}
+	}
+
+	if !hasCondition {
+		return 0, errors.New("missing WHERE condition for updating operator")
+	}
+
+	// Apply global scopes of the model and exclude soft deleted rows.
+	db.applyGlobalScopes(db.model)
+	db.applySoftDelete(table)
+
+	updateBuilder := qb.UpdateInstance().
+		Update(table.Name).
+		WhereCondition(db.whereStatement.Conditions...)
+
+	for _, item := range db.setStatement.Items {
+		updateBuilder.Set(item.Field, item.Value)
+	}
+
+	if err = db.update(updateBuilder); err != nil {
+		return 0, err
+	}
+
+	return db.rowsAffected, db.strictRowsAffected()
+}
@@ -0,0 +1,98 @@
+package db
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	qb "github.com/jivegroup/fluentsql"
+)
+
+type updateAccount struct {
+	MetaData  MetaData     `db:"-" model:"table:accounts"`
+	ID        int          `db:"id" model:"name:id; type:serial,primary"`
+	Status    string       `db:"status" model:"name:status"`
+	Logins    int          `db:"logins" model:"name:logins"`
+	DeletedAt sql.NullTime `db:"deleted_at" model:"name:deleted_at; soft_delete"`
+}
+
+func TestUpdateColumns(t *testing.T) {
+	tests := []struct {
+		name         string
+		update       func(db *DBModel) (int64, error)
+		expected     string
+		expectedArgs []any
+	}{
+		{
+			name: "excludes soft deleted rows",
+			update: func(db *DBModel) (int64, error) {
+				return db.Model(&updateAccount{}).Where("status", Eq, "pending").
+					UpdateColumns(map[string]any{"status": "active"})
+			},
+			expected:     "UPDATE accounts SET status = $1 WHERE status = $2 AND accounts.deleted_at IS NULL",
+			expectedArgs: []any{"active", "pending"},
+		},
+		{
+			name: "with trashed rows",
+			update: func(db *DBModel) (int64, error) {
+				return db.Model(&updateAccount{}).Where("status", Eq, "pending").WithTrashed().
+					UpdateColumns(map[string]any{"status": "active"})
+			},
+			expected:     "UPDATE accounts SET status = $1 WHERE status = $2",
+			expectedArgs: []any{"active", "pending"},
+		},
+		{
+			name: "by primary key",
+			update: func(db *DBModel) (int64, error) {
+				return db.Model(&updateAccount{ID: 3}).Increment("logins", 1)
+			},
+			expected:     "UPDATE accounts SET logins = logins + $1 WHERE id = $2 AND accounts.deleted_at IS NULL",
+			expectedArgs: []any{int64(1), int64(3)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeDB(t, new(qb.PostgreSQLDialect), fakeResult{affected: 2})
+
+			count, err := tt.update(Instance())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if count != 2 {
+				t.Errorf("count = %d, expected 2", count)
+			}
+
+			if len(fake.statements) != 1 || fake.statements[0] != tt.expected {
+				t.Fatalf("statements = %q, expected %q", fake.statements, tt.expected)
+			}
+
+			args := make([]any, len(fake.args[0]))
+			for i, arg := range fake.args[0] {
+				args[i] = arg
+			}
+
+			if !reflect.DeepEqual(args, tt.expectedArgs) {
+				t.Errorf("args = %v, expected %v", args, tt.expectedArgs)
+			}
+		})
+	}
+}
+
+func TestUpdateColumnsWithoutValue(t *testing.T) {
+	fake := useFakeDB(t, new(qb.PostgreSQLDialect))
+
+	db := Instance().Model(&updateAccount{}).Where("status", Eq, "pending")
+	if _, err := db.UpdateColumns(nil); err == nil {
+		t.Error("UpdateColumns(nil) expected an error")
+	}
+
+	if len(fake.statements) != 0 {
+		t.Errorf("statements = %q, expected none", fake.statements)
+	}
+
+	if len(db.whereStatement.Conditions) != 0 {
+		t.Error("UpdateColumns(nil) expected the query to be reset")
+	}
+}
@@ -0,0 +1,91 @@
+package db
+
+import (
+	qb "github.com/jivegroup/fluentsql"
+	"reflect"
+)
+
+// ====================================================================
+//                        Optimistic locking
+// ====================================================================
+
+// versionCondition returns the WHERE condition checking the version held by the model.
+// Models holding a zero version (not read from the database) are not checked.
+//
+// Returns:
+//   - qb.Condition: The condition on the version column.
+//   - bool: Whether the version must be checked.
+func (tbl *Table) versionCondition() (qb.Condition, bool) {
+	if tbl.Version == nil || tbl.Version.IsZero {
+		return qb.Condition{}, false
+	}
+
+	return qb.Condition{
+		Field: tbl.Version.Name,
+		Opt:   Eq,
+		Value: tbl.Values[tbl.Version.Name],
+		AndOr: And,
+	}, true
+}
+
+// staleObjectError returns the error of an update or deletion rejected by optimistic locking.
+//
+// Returns:
+//   - error: The StaleObjectError.
+func (tbl *Table) staleObjectError() error {
+	return StaleObjectError{
+		Table:   tbl.Name,
+		Version: tbl.Values[tbl.Version.Name],
+	}
+}
+
+// setInitialVersion sets the version of a created model to 1 when it is zero.
+//
+// Parameters:
+//   - model (any): The model. When a pointer is given, its version field is set as well.
+func (tbl *Table) setInitialVersion(model any) {
+	if tbl.Version == nil || !tbl.Version.IsZero {
+		return
+	}
+
+	for i := range tbl.Columns {
+		column := &tbl.Columns[i]
+		if !column.Version {
+			continue
+		}
+
+		tbl.Values[column.Name] = 1
+		column.HasValue = true
+		column.IsZero = false
+
+		if isStructPointer(model) {
+			field := reflect.ValueOf(model).Elem().FieldByName(column.Key)
+			if field.CanInt() {
+				field.SetInt(1)
+				tbl.Values[column.Name] = field.Interface()
+			} else if field.CanUint() {
+				field.SetUint(1)
+				tbl.Values[column.Name] = field.Interface()
+			}
+		}
+	}
+}
+
+// incrementVersion increments the version field of an updated model.
+//
+// Parameters:
+//   - model (any): The model. Only pointers are changed.
+func (tbl *Table) incrementVersion(model any) {
+	if tbl.Version == nil || tbl.Version.IsZero || !isStructPointer(model) {
+		return
+	}
+
+	field := reflect.ValueOf(model).Elem().FieldByName(tbl.Version.Key)
+
+	switch {
+	case field.CanInt():
+		field.SetInt(field.Int() + 1)
+	case field.CanUint():
+		field.SetUint(field.Uint() + 1)
+	}
+}
@@ -0,0 +1,145 @@
+package db
+
+import (
+	"errors"
+	"testing"
+
+	qb "github.com/jivegroup/fluentsql"
+)
+
+type versionDoc struct {
+	MetaData MetaData `db:"-" model:"table:docs"`
+	ID       int      `db:"id" model:"name:id; type:serial,primary"`
+	Title    string   `db:"title" model:"name:title"`
+	Version  int      `db:"version" model:"name:version; version"`
+}
+
+func TestVersion(t *testing.T) {
+	tests := []struct {
+		name            string
+		run             func(db *DBModel, doc *versionDoc) error
+		doc             versionDoc
+		affected        int64
+		expected        string
+		expectedVersion int
+		expectedStale   bool
+	}{
+		{
+			name: "Update increments the version",
+			run: func(db *DBModel, doc *versionDoc) error {
+				return db.Update(doc)
+			},
+			doc:             versionDoc{ID: 1, Title: "Hello", Version: 3},
+			affected:        1,
+			expected:        "UPDATE docs SET title = $1, version = version + 1 WHERE id = $2 AND version = $3",
+			expectedVersion: 4,
+		},
+		{
+			name: "Update of a stale version",
+			run: func(db *DBModel, doc *versionDoc) error {
+				return db.Update(doc)
+			},
+			doc:             versionDoc{ID: 1, Title: "Hello", Version: 3},
+			affected:        0,
+			expected:        "UPDATE docs SET title = $1, version = version + 1 WHERE id = $2 AND version = $3",
+			expectedVersion: 3,
+			expectedStale:   true,
+		},
+		{
+			name: "Update without version read",
+			run: func(db *DBModel, doc *versionDoc) error {
+				return db.Update(doc)
+			},
+			doc:             versionDoc{ID: 1, Title: "Hello"},
+			affected:        0,
+			expected:        "UPDATE docs SET title = $1, version = version + 1 WHERE id = $2",
+			expectedVersion: 0,
+		},
+		{
+			name: "Delete checks the version",
+			run: func(db *DBModel, doc *versionDoc) error {
+				return db.Delete(doc)
+			},
+			doc:             versionDoc{ID: 1, Version: 3},
+			affected:        1,
+			expected:        "DELETE FROM docs WHERE id = $1 AND version = $2",
+			expectedVersion: 3,
+		},
+		{
+			name: "Delete of a stale version",
+			run: func(db *DBModel, doc *versionDoc) error {
+				return db.Delete(doc)
+			},
+			doc:             versionDoc{ID: 1, Version: 3},
+			affected:        0,
+			expected:        "DELETE FROM docs WHERE id = $1 AND version = $2",
+			expectedVersion: 3,
+			expectedStale:   true,
+		},
+		{
+			name: "Delete without version read",
+			run: func(db *DBModel, doc *versionDoc) error {
+				return db.Delete(doc)
+			},
+			doc:      versionDoc{ID: 1},
+			affected: 0,
+			expected: "DELETE FROM docs WHERE id = $1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeDB(t, new(qb.PostgreSQLDialect), fakeResult{affected: tt.affected})
+
+			doc := tt.doc
+			err := tt.run(Instance(), &doc)
+
+			if len(fake.statements) != 1 || fake.statements[0] != tt.expected {
+				t.Errorf("statements = %q, expected %q", fake.statements, tt.expected)
+			}
+
+			if doc.Version != tt.expectedVersion {
+				t.Errorf("Version = %d, expected %d", doc.Version, tt.expectedVersion)
+			}
+
+			if !tt.expectedStale {
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, ErrStaleObject) {
+				t.Fatalf("error = %v, expected ErrStaleObject", err)
+			}
+
+			var staleErr StaleObjectError
+			if !errors.As(err, &staleErr) || staleErr.Table != "docs" || staleErr.Version != 3 {
+				t.Errorf("error = %#v, expected the table docs at version 3", err)
+			}
+		})
+	}
+}
+
+func TestCreateDataInitialVersion(t *testing.T) {
+	doc := versionDoc{Title: "Hello"}
+	table, err := CreateData(&doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.Version != 1 || table.Values["version"] != 1 {
+		t.Errorf("Version = %d, value = %v, expected 1", doc.Version, table.Values["version"])
+	}
+
+	// A version set by the caller is kept
+	doc = versionDoc{Title: "Hello", Version: 5}
+	if _, err = CreateData(&doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.Version != 5 {
+		t.Errorf("Version = %d, expected 5", doc.Version)
+	}
+}